@@ -1,11 +1,11 @@
 package codeowners
 
 import (
-	"bufio"
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
-	"strings"
+	"sort"
 )
 
 var availableCheckers map[string]Checker
@@ -14,7 +14,7 @@ func init() {
 	availableCheckers = make(map[string]Checker)
 }
 
-// AvailableCheckers returns list of registered checkers
+// AvailableCheckers returns list of registered checkers sorted by name
 func AvailableCheckers() []string {
 	names := make([]string, len(availableCheckers))
 	i := 0
@@ -22,9 +22,25 @@ func AvailableCheckers() []string {
 		names[i] = checkerName
 		i++
 	}
+	sort.Strings(names)
 	return names
 }
 
+// DescribeCheckers returns the metadata of all registered checkers sorted by name.
+// Checkers not implementing Describer only have their name filled.
+func DescribeCheckers() []CheckerInfo {
+	names := AvailableCheckers()
+
+	infos := make([]CheckerInfo, len(names))
+	for i, name := range names {
+		if describer, ok := availableCheckers[name].(Describer); ok {
+			infos[i] = describer.Describe()
+		}
+		infos[i].Name = name
+	}
+	return infos
+}
+
 // RegisterChecker adds checker to be used later when checking CODEOWNERS files
 func RegisterChecker(name string, checker Checker) error {
 	_, found := availableCheckers[name]
@@ -35,12 +51,28 @@ func RegisterChecker(name string, checker Checker) error {
 	return nil
 }
 
-// Check evaluates the file contents against the checkers and return the results back.
+// Check evaluates the file contents against the checkers and return the results back, see CheckContext.
 func Check(options CheckOptions) ([]CheckResult, error) {
+	return CheckContext(context.Background(), options)
+}
+
+// CheckContext evaluates the file contents against the checkers and return the results back.
+// The context is passed down to validators, once it is done the check stops and returns its error.
+// Results silenced by suppression comments are left out, see applySuppressions.
+//
+// Results are always returned in a stable order: sorted by file path, line, column and check name,
+// results sharing all of these keep the order of options.Checkers and then the order the checker reported them.
+func CheckContext(ctx context.Context, options CheckOptions) ([]CheckResult, error) {
 
-	fileLocation, result := findCodeownersFile(options.Directory)
-	if result != nil {
-		return []CheckResult{*result}, nil
+	profile, err := PlatformProfile(options.Platform)
+	if err != nil {
+		return nil, err
+	}
+
+	fileLocation, fileResults := profile.findCodeownersFile(options.Directory)
+	if len(fileLocation) == 0 {
+		applySeverities(fileResults, options.Severities)
+		return fileResults, nil
 	}
 
 	file, err := os.Open(filepath.Join(options.Directory, fileLocation))
@@ -49,35 +81,87 @@ func Check(options CheckOptions) ([]CheckResult, error) {
 	}
 	defer file.Close()
 
+	parsed, err := ParseDialect(file, profile.Dialect)
+	if err != nil {
+		return nil, err
+	}
+
 	results := []CheckResult{}
-	scanner := bufio.NewScanner(file)
-	lineNo := 0
 
-	validators := make(map[string]Validator)
+	validators := []Validator{}
 	for _, checker := range options.Checkers {
 		c, ok := availableCheckers[checker]
 		if !ok {
 			return nil, fmt.Errorf("'%s' not found", checker)
 		}
-		validators[checker] = c.NewValidator(ValidatorOptions{
-			Directory:              options.Directory,
-			CodeownersFileLocation: fileLocation,
-			GithubToken:            options.GithubToken,
-			GithubTokenType:        options.GithubTokenType,
-		})
+		if optionsValidator, ok := c.(OptionsValidator); ok {
+			if err := optionsValidator.ValidateOptions(options.CheckerOptions[checker]); err != nil {
+				return nil, fmt.Errorf("'%s': %v", checker, err)
+			}
+		}
+		validators = append(validators, c.NewValidator(ValidatorOptions{
+			Context:                 ctx,
+			Directory:               options.Directory,
+			CodeownersFileLocation:  fileLocation,
+			GithubToken:             options.GithubToken,
+			GithubTokenType:         options.GithubTokenType,
+			GithubURL:               options.GithubURL,
+			GithubUploadURL:         options.GithubUploadURL,
+			CAFile:                  options.CAFile,
+			Proxy:                   options.Proxy,
+			GithubAppID:             options.GithubAppID,
+			GithubAppPrivateKeyFile: options.GithubAppPrivateKeyFile,
+			GithubAppInstallationID: options.GithubAppInstallationID,
+			Repository:              options.Repository,
+			Remote:                  options.Remote,
+			Platform:                options.Platform,
+			GitlabURL:               options.GitlabURL,
+			GitlabToken:             options.GitlabToken,
+			GiteaURL:                options.GiteaURL,
+			GiteaToken:              options.GiteaToken,
+			Options:                 options.CheckerOptions[checker],
+		}))
+	}
+
+	addResults := func(validatorResults []CheckResult) {
+		results = append(results, validatorResults...)
+	}
+	addResults(fileResults)
+
+	fileValidators := []FileValidator{}
+	for _, v := range validators {
+		if fileValidator, ok := v.(FileValidator); ok {
+			fileValidator.Begin(parsed)
+			fileValidators = append(fileValidators, fileValidator)
+		}
 	}
 
-	for scanner.Scan() {
-		line := scanner.Text()
-		lineNo++
+	for _, node := range parsed.Nodes {
+		if err = ctx.Err(); err != nil {
+			return nil, err
+		}
 		for _, c := range validators {
-			lineResults := c.ValidateLine(lineNo, line)
-			if lineResults != nil {
-				results = append(results, lineResults...)
-			}
+			addResults(c.ValidateLine(node.Span.Line, node.Raw))
+		}
+		if node.Kind != RuleNode {
+			continue
 		}
+		for _, c := range fileValidators {
+			addResults(c.ValidateRule(node))
+		}
+	}
+
+	if err = ctx.Err(); err != nil {
+		return nil, err
+	}
+	for _, c := range fileValidators {
+		addResults(c.Finish())
 	}
 
+	results = applySuppressions(fileLocation, parsed, options.Checkers, results)
+	applySeverities(results, options.Severities)
+	SortResults(results)
+
 	if len(results) > 0 {
 		return results, nil
 	}
@@ -85,32 +169,39 @@ func Check(options CheckOptions) ([]CheckResult, error) {
 	return nil, nil
 }
 
-func fileExists(file string) bool {
-	info, err := os.Stat(file)
-	return !os.IsNotExist(err) && !info.IsDir()
-}
-
-func findCodeownersFile(dir string) (string, *CheckResult) {
-	codeownersLocation := ""
-
-	filesFound := []string{}
-	for _, fileLocation := range DefaultLocations {
-		currentFile := filepath.Join(dir, fileLocation)
-		if fileExists(currentFile) {
-			filesFound = append(filesFound, fileLocation)
-			if len(codeownersLocation) == 0 {
-				codeownersLocation = fileLocation
-			}
+// applySeverities overrides the severity of results by check name, results of suppressions and of finding the file included
+func applySeverities(results []CheckResult, severities map[string]SeverityLevel) {
+	for i, result := range results {
+		if severity, found := severities[result.CheckName]; found {
+			results[i].Severity = severity
 		}
 	}
+}
 
-	if len(filesFound) == 0 {
-		return "", &CheckResult{Position: Position{FilePath: "CODEOWNERS"}, Message: "No CODEOWNERS file found", Severity: Error, CheckName: "NoCodeowners"}
-	}
+// SortResults orders results by file path, line, column and check name
+func SortResults(results []CheckResult) {
+	sort.SliceStable(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if a.Position.FilePath != b.Position.FilePath {
+			return a.Position.FilePath < b.Position.FilePath
+		}
+		if a.Position.StartLine != b.Position.StartLine {
+			return a.Position.StartLine < b.Position.StartLine
+		}
+		if a.Position.StartColumn != b.Position.StartColumn {
+			return a.Position.StartColumn < b.Position.StartColumn
+		}
+		return a.CheckName < b.CheckName
+	})
+}
 
-	if len(filesFound) > 1 {
-		return "", &CheckResult{Position: Position{FilePath: codeownersLocation}, Message: fmt.Sprintf("Multiple CODEOWNERS files found (%s)", strings.Join(filesFound, ", ")), Severity: Warning, CheckName: "MultipleCodeowners"}
-	}
+func fileExists(file string) bool {
+	info, err := os.Stat(file)
+	return !os.IsNotExist(err) && !info.IsDir()
+}
 
-	return codeownersLocation, nil
+// FindCodeownersFile returns the location, relative to the given directory, of the CODEOWNERS file in use following GithubProfile,
+// see Profile.FindCodeownersFile
+func FindCodeownersFile(dir string) (string, error) {
+	return GithubProfile.FindCodeownersFile(dir)
 }
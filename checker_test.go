@@ -1,8 +1,10 @@
 package codeowners_test
 
 import (
+	"context"
 	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/fmenezes/codeowners"
@@ -232,9 +234,19 @@ func TestMultipleCodeownersCheck(t *testing.T) {
 	want := []codeowners.CheckResult{
 		{
 			Position: codeowners.Position{
-				FilePath: "CODEOWNERS",
+				FilePath:  "CODEOWNERS",
+				StartLine: 1,
+				EndLine:   1,
 			},
-			Message:   "Multiple CODEOWNERS files found (CODEOWNERS, docs/CODEOWNERS)",
+			Message:   "Dummy Error",
+			Severity:  codeowners.Error,
+			CheckName: dummyCheckerName,
+		},
+		{
+			Position: codeowners.Position{
+				FilePath: "docs/CODEOWNERS",
+			},
+			Message:   "CODEOWNERS file is shadowed by CODEOWNERS, which github reads first",
 			Severity:  codeowners.Warning,
 			CheckName: "MultipleCodeowners",
 		},
@@ -266,3 +278,193 @@ func ExampleCheck() {
 	//Output:
 	//CODEOWNERS 0 ::Error:: No CODEOWNERS file found [NoCodeowners]
 }
+
+func TestSeverityOverrideCheck(t *testing.T) {
+	input := "./test/data/pass"
+	codeowners.RegisterChecker(dummyCheckerName, dummyChecker{})
+	got, err := codeowners.Check(codeowners.CheckOptions{
+		Directory:  input,
+		Checkers:   []string{dummyCheckerName},
+		Severities: map[string]codeowners.SeverityLevel{dummyCheckerName: codeowners.Warning},
+	})
+	if err != nil {
+		t.Errorf("Input %s, Error %v", input, err)
+	}
+	if len(got) != 1 || got[0].Severity != codeowners.Warning {
+		t.Errorf("Input %s, Want %v severity, Got %v", input, codeowners.Warning, got)
+	}
+}
+
+func TestSeverityOverrideBuiltinChecks(t *testing.T) {
+	tests := []struct {
+		input     string
+		checkName string
+		want      codeowners.SeverityLevel
+	}{
+		{input: "./test/data/suppressions", checkName: "UnusedSuppression", want: codeowners.Error},
+		{input: "./test/data/suppressions", checkName: "InvalidSuppression", want: codeowners.Error},
+		{input: "./test/data", checkName: "NoCodeowners", want: codeowners.Warning},
+	}
+	codeowners.RegisterChecker(badCheckerName, badChecker{})
+	for _, test := range tests {
+		got, err := codeowners.Check(codeowners.CheckOptions{
+			Directory:  test.input,
+			Checkers:   []string{badCheckerName},
+			Severities: map[string]codeowners.SeverityLevel{test.checkName: test.want},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		found := false
+		for _, result := range got {
+			if result.CheckName != test.checkName {
+				continue
+			}
+			found = true
+			if result.Severity != test.want {
+				t.Errorf("Input: %v, Want: %v, Got: %v", test, test.want, result)
+			}
+		}
+		if !found {
+			t.Errorf("Input: %v, Want: %s results, Got: %v", test, test.checkName, got)
+		}
+	}
+}
+
+func TestDescribeCheckers(t *testing.T) {
+	codeowners.RegisterChecker(dummyCheckerName, dummyChecker{})
+	infos := codeowners.DescribeCheckers()
+	found := false
+	for i, info := range infos {
+		if i > 0 && infos[i-1].Name > info.Name {
+			t.Errorf("Checkers not sorted: %s before %s", infos[i-1].Name, info.Name)
+		}
+		if info.Name == dummyCheckerName {
+			found = true
+			if !reflect.DeepEqual(info, codeowners.CheckerInfo{Name: dummyCheckerName}) {
+				t.Errorf("Want: %v, Got: %v", codeowners.CheckerInfo{Name: dummyCheckerName}, info)
+			}
+		}
+	}
+	if !found {
+		t.Errorf("%s not described", dummyCheckerName)
+	}
+}
+
+type unorderedChecker struct{}
+
+func (c unorderedChecker) NewValidator(options codeowners.ValidatorOptions) codeowners.Validator {
+	return c
+}
+
+func (c unorderedChecker) ValidateLine(lineNo int, line string) []codeowners.CheckResult {
+	return []codeowners.CheckResult{
+		{Position: codeowners.Position{FilePath: "CODEOWNERS", StartLine: lineNo, StartColumn: 5}, CheckName: "Unordered"},
+		{Position: codeowners.Position{FilePath: "CODEOWNERS", StartLine: lineNo, StartColumn: 1}, CheckName: "Unordered"},
+		{Position: codeowners.Position{FilePath: "CODEOWNERS", StartLine: lineNo}, CheckName: "Unordered"},
+	}
+}
+
+func TestCheckResultsOrder(t *testing.T) {
+	input := "./test/data/pass"
+	want := []string{"0 Unordered", "0 dummy", "1 Unordered", "5 Unordered"}
+
+	codeowners.RegisterChecker(dummyCheckerName, dummyChecker{})
+	codeowners.RegisterChecker("Unordered", unorderedChecker{})
+	for i := 0; i < 10; i++ {
+		results, err := codeowners.Check(codeowners.CheckOptions{
+			Directory: input,
+			Checkers:  []string{dummyCheckerName, "Unordered"},
+		})
+		if err != nil {
+			t.Fatalf("Input %s, Error %v", input, err)
+		}
+		got := []string{}
+		for _, result := range results {
+			got = append(got, fmt.Sprintf("%d %s", result.Position.StartColumn, result.CheckName))
+		}
+		if !reflect.DeepEqual(want, got) {
+			t.Fatalf("Input %s, Want %v, Got %v", input, want, got)
+		}
+	}
+}
+
+const fileCheckerName string = "file"
+
+type fileChecker struct{}
+
+type fileCheckerValidator struct {
+	codeownersFileLocation string
+	calls                  []string
+}
+
+func (c fileChecker) NewValidator(options codeowners.ValidatorOptions) codeowners.Validator {
+	return &fileCheckerValidator{
+		codeownersFileLocation: options.CodeownersFileLocation,
+	}
+}
+
+func (c *fileCheckerValidator) ValidateLine(lineNo int, line string) []codeowners.CheckResult {
+	c.calls = append(c.calls, fmt.Sprintf("line %d", lineNo))
+	return nil
+}
+
+func (c *fileCheckerValidator) Begin(file *codeowners.File) {
+	c.calls = append(c.calls, fmt.Sprintf("begin %d", len(file.Nodes)))
+}
+
+func (c *fileCheckerValidator) ValidateRule(rule codeowners.Node) []codeowners.CheckResult {
+	c.calls = append(c.calls, fmt.Sprintf("rule %s", rule.Pattern.Value))
+	return nil
+}
+
+func (c *fileCheckerValidator) Finish() []codeowners.CheckResult {
+	c.calls = append(c.calls, "finish")
+	return []codeowners.CheckResult{
+		{
+			Position:  codeowners.Position{FilePath: c.codeownersFileLocation},
+			Message:   strings.Join(c.calls, ", "),
+			Severity:  codeowners.Warning,
+			CheckName: fileCheckerName,
+		},
+	}
+}
+
+func TestFileValidatorCheck(t *testing.T) {
+	input := "./test/data/pass"
+	want := []codeowners.CheckResult{
+		{
+			Position:  codeowners.Position{FilePath: "CODEOWNERS"},
+			Message:   "begin 1, line 1, rule file1.txt, finish",
+			Severity:  codeowners.Warning,
+			CheckName: fileCheckerName,
+		},
+	}
+
+	codeowners.RegisterChecker(fileCheckerName, fileChecker{})
+	got, err := codeowners.Check(codeowners.CheckOptions{
+		Directory: input,
+		Checkers:  []string{fileCheckerName},
+	})
+	if err != nil {
+		t.Errorf("Input %s, Error %v", input, err)
+	}
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("Input %s, Want %v, Got %v", input, want, got)
+	}
+}
+
+func TestCheckContextCanceled(t *testing.T) {
+	input := "./test/data/pass"
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	codeowners.RegisterChecker(dummyCheckerName, dummyChecker{})
+	got, err := codeowners.CheckContext(ctx, codeowners.CheckOptions{
+		Directory: input,
+		Checkers:  []string{dummyCheckerName},
+	})
+	if err != context.Canceled || got != nil {
+		t.Errorf("Input %s, Want %v, Got %v %v", input, context.Canceled, got, err)
+	}
+}
@@ -1,13 +1,40 @@
 package checkers
 
 import (
+	"context"
 	"fmt"
-	"strings"
+	"strconv"
+	"sync"
+	"time"
 
 	"github.com/fmenezes/codeowners"
 )
 
 const accessCheckerName string = "Access"
+const accessCheckFailedName string = "AccessCheckFailed"
+
+// AccessFailuresOption is the Access checker option deciding how failures to check an owner's access are reported:
+// "error" (default), "warning" or "skip"
+const AccessFailuresOption string = "failures"
+
+// AccessConcurrencyOption is the Access checker option limiting how many owners are looked up in parallel,
+// it defaults to DefaultAccessConcurrency
+const AccessConcurrencyOption string = "concurrency"
+
+// AccessSnapshotOption is the Access checker option pointing to a JSON or YAML snapshot file, see Snapshot.
+// When set owners are checked against the snapshot instead of calling Github
+const AccessSnapshotOption string = "snapshot"
+
+// AccessCacheDirOption is the Access checker option setting the directory where owner access is cached between runs,
+// nothing is cached when empty
+const AccessCacheDirOption string = "cache-dir"
+
+// AccessCacheTTLOption is the Access checker option setting how long cached owner access is trusted, such as 30m or 24h,
+// it defaults to DefaultAccessCacheTTL
+const AccessCacheTTLOption string = "cache-ttl"
+
+// DefaultAccessConcurrency is the number of owners looked up in parallel when AccessConcurrencyOption is not set
+const DefaultAccessConcurrency int = 8
 
 func init() {
 	codeowners.RegisterChecker(accessCheckerName, Access{})
@@ -16,57 +43,241 @@ func init() {
 // Access represents checker to validate if an owner has access to repo
 type Access struct{}
 
+// Describe returns metadata about this checker
+func (c Access) Describe() codeowners.CheckerInfo {
+	return codeowners.CheckerInfo{
+		Description:   "Owners must have write access to the repository",
+		Severity:      codeowners.Error,
+		RequiresToken: true,
+		ResultNames:   []string{accessCheckFailedName},
+	}
+}
+
+// ValidateOptions rejects unknown AccessFailuresOption values
+func (c Access) ValidateOptions(options map[string]string) error {
+	failures := options[AccessFailuresOption]
+	if len(failures) == 0 || failures == "skip" {
+		return nil
+	}
+	if _, err := codeowners.ParseSeverityLevel(failures); err != nil {
+		return fmt.Errorf("Invalid %s '%s', expected error, warning or skip", AccessFailuresOption, failures)
+	}
+	return nil
+}
+
 // NewValidator returns validating capabilities for this checker
 func (c Access) NewValidator(options codeowners.ValidatorOptions) codeowners.Validator {
-	return accessValidator{
+	v := &accessValidator{
 		options:    options,
-		accessMemo: make(map[string]bool),
+		accessMemo: make(map[string]accessResult),
+	}
+	if file := options.Options[AccessSnapshotOption]; len(file) > 0 {
+		snapshot, err := LoadSnapshot(file)
+		if err == nil {
+			err = snapshot.checkRepository(options)
+		}
+		if err != nil {
+			v.sourceErr = err
+		} else {
+			v.source = resolverSource{snapshot}
+		}
+		return v
+	}
+	if !hasCredentials(options) {
+		return v
 	}
+
+	resolver, host, err := newOwnerResolver(options)
+	if err != nil {
+		v.sourceErr = err
+		return v
+	}
+	v.source = resolverSource{resolver}
+	if dir := options.Options[AccessCacheDirOption]; len(dir) > 0 {
+		ttl, err := time.ParseDuration(options.Options[AccessCacheTTLOption])
+		if err != nil || ttl <= 0 {
+			ttl = DefaultAccessCacheTTL
+		}
+		v.source = &cachedSource{source: v.source, host: host, cache: newAccessCache(dir, ttl)}
+	}
+	return v
+}
+
+// accessSource tells whether owners have write access to the repository,
+// it is an OwnerResolver of the hosting platform or of an offline snapshot, possibly cached
+type accessSource interface {
+	ownerHasWriteAccess(owner string) (bool, error)
+	repository() string
+}
+
+type accessResult struct {
+	writeAccess bool
+	err         error
 }
 
 type accessValidator struct {
 	options    codeowners.ValidatorOptions
-	accessMemo map[string]bool
+	memoMutex  sync.Mutex
+	accessMemo map[string]accessResult
+	source     accessSource // source is nil when there is nothing to check owners against
+	sourceErr  error        // sourceErr is set when the source could not be set up, it is reported once for the whole file
+}
+
+// ownerValid tells whether owner is well formed for the platform, malformed owners are left to InvalidOwner
+func (v *accessValidator) ownerValid(owner string) bool {
+	valid, _ := dialectOwnerValid(owner, codeowners.PlatformDialect(v.options.Platform))
+	return valid
+}
+
+// concurrency returns the maximum number of parallel lookups, falling back to DefaultAccessConcurrency when unset or invalid
+func (v *accessValidator) concurrency() int {
+	value, err := strconv.Atoi(v.options.Options[AccessConcurrencyOption])
+	if err != nil || value < 1 {
+		return DefaultAccessConcurrency
+	}
+	return value
+}
+
+// Begin prefetches the access of every distinct valid owner in the file in parallel, default owners of sections included,
+// at most concurrency lookups run at the same time. No more lookups are started once the context is done.
+func (v *accessValidator) Begin(file *codeowners.File) {
+	if v.source == nil {
+		return
+	}
+
+	owners := []string{}
+	seen := make(map[string]bool)
+	for _, node := range file.Nodes {
+		for _, owner := range node.Owners {
+			if !v.ownerValid(owner.Value) || seen[owner.Value] {
+				continue
+			}
+			seen[owner.Value] = true
+			owners = append(owners, owner.Value)
+		}
+	}
+
+	workers := v.concurrency()
+	if workers > len(owners) {
+		workers = len(owners)
+	}
+
+	jobs := make(chan string)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for owner := range jobs {
+				v.lookup(owner)
+			}
+		}()
+	}
+	ctx := v.options.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+dispatch:
+	for _, owner := range owners {
+		if ctx.Err() != nil {
+			break
+		}
+		select {
+		case jobs <- owner:
+		case <-ctx.Done():
+			break dispatch
+		}
+	}
+	close(jobs)
+	wg.Wait()
+}
+
+// ValidateRule does nothing, results are reported by ValidateLine once owners are prefetched
+func (v *accessValidator) ValidateRule(node codeowners.Node) []codeowners.CheckResult {
+	return nil
 }
 
-// ValidateLine runs this NoOwner's check against each line
-func (v accessValidator) ValidateLine(lineNo int, line string) []codeowners.CheckResult {
-	if len(v.options.GithubToken) == 0 {
+// Finish reports once for the whole file when owners could not be looked up at all, such as when the repository
+// could not be resolved, other results are reported by ValidateLine once owners are prefetched
+func (v *accessValidator) Finish() []codeowners.CheckResult {
+	if v.sourceErr == nil {
+		return nil
+	}
+	position := codeowners.Position{FilePath: v.options.CodeownersFileLocation}
+	if result := v.failureResult(position, fmt.Sprintf("Could not check access of owners: %v", v.sourceErr), v.sourceErr); result != nil {
+		return []codeowners.CheckResult{*result}
+	}
+	return nil
+}
+
+// lookup returns the memoised access of owner, fetching it when it was not looked up yet
+func (v *accessValidator) lookup(owner string) accessResult {
+	v.memoMutex.Lock()
+	access, found := v.accessMemo[owner]
+	v.memoMutex.Unlock()
+	if found {
+		return access
+	}
+
+	access.writeAccess, access.err = v.source.ownerHasWriteAccess(owner)
+
+	v.memoMutex.Lock()
+	v.accessMemo[owner] = access
+	v.memoMutex.Unlock()
+	return access
+}
+
+// failureResult reports access that could not be checked, it returns nil when failures are skipped.
+// The failures option is checked by ValidateOptions, failures are errors unless it says otherwise.
+func (v *accessValidator) failureResult(position codeowners.Position, message string, err error) *codeowners.CheckResult {
+	failures := v.options.Options[AccessFailuresOption]
+	if failures == "skip" {
+		return nil
+	}
+	severity, _ := codeowners.ParseSeverityLevel(failures)
+
+	return &codeowners.CheckResult{
+		Position:  position,
+		Message:   message,
+		Severity:  severity,
+		CheckName: accessCheckFailedName,
+		Cause:     err,
+	}
+}
+
+// ValidateLine runs this Access's check against each line, nothing is reported per owner when owners cannot be looked up at all
+func (v *accessValidator) ValidateLine(lineNo int, line string) []codeowners.CheckResult {
+	if v.source == nil {
 		return nil
 	}
 
 	results := []codeowners.CheckResult{}
 
-	_, owners := codeowners.ParseLine(line)
+	node := codeowners.ParseDialectNode(lineNo, line, codeowners.PlatformDialect(v.options.Platform))
 
-	if len(owners) == 0 {
+	if len(node.Owners) == 0 {
 		return nil
 	}
 
-	for _, owner := range owners {
-		if !ownerValid(owner) {
+	for _, owner := range node.Owners {
+		if !v.ownerValid(owner.Value) {
 			continue
 		}
-		writeAccess, found := v.accessMemo[owner]
-		if !found {
-			writeAccess, _ = ownerHasWriteAccess(v.options, owner)
-			v.accessMemo[owner] = writeAccess
+		access := v.lookup(owner.Value)
+		if access.err != nil {
+			message := fmt.Sprintf("Could not check access of owner '%s': %v", owner.Value, access.err)
+			if result := v.failureResult(owner.Span.Position(v.options.CodeownersFileLocation), message, access.err); result != nil {
+				results = append(results, *result)
+			}
+			continue
 		}
-		if !writeAccess {
-			result := codeowners.CheckResult{
-				Position: codeowners.Position{
-					FilePath:    v.options.CodeownersFileLocation,
-					StartLine:   lineNo,
-					EndLine:     lineNo,
-					StartColumn: strings.Index(line, owner) + 1,
-				},
-				Message:   fmt.Sprintf("Owner '%s' has no write access", owner),
+		if !access.writeAccess {
+			results = append(results, codeowners.CheckResult{
+				Position:  owner.Span.Position(v.options.CodeownersFileLocation),
+				Message:   fmt.Sprintf("Owner '%s' has no write access to %s", owner.Value, v.source.repository()),
 				Severity:  codeowners.Error,
 				CheckName: accessCheckerName,
-			}
-			result.Position.EndColumn = result.Position.StartColumn + len(owner)
-
-			results = append(results, result)
+			})
 		}
 	}
 
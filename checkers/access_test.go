@@ -8,6 +8,31 @@ import (
 	"github.com/fmenezes/codeowners/checkers"
 )
 
+func assertAccessCheckFailed(t *testing.T, input interface{}, got []codeowners.CheckResult, want codeowners.Position, severity codeowners.SeverityLevel, owner string) {
+	t.Helper()
+	if len(got) != 1 {
+		t.Fatalf("Input: %v, Want: 1 result, Got: %v", input, got)
+	}
+	wantMessage := "Could not check access of owner '" + owner + "': "
+	if got[0].CheckName != "AccessCheckFailed" || got[0].Severity != severity || got[0].Position != want ||
+		got[0].Cause == nil || got[0].Message != wantMessage+got[0].Cause.Error() {
+		t.Errorf("Input: %v, Want: %v %v %s..., Got: %v", input, want, severity, wantMessage, got)
+	}
+}
+
+// assertSourceFailed checks owners could not be looked up at all is reported once for the whole file
+func assertSourceFailed(t *testing.T, input interface{}, got []codeowners.CheckResult, want codeowners.Position) {
+	t.Helper()
+	if len(got) != 1 {
+		t.Fatalf("Input: %v, Want: 1 result, Got: %v", input, got)
+	}
+	wantMessage := "Could not check access of owners: "
+	if got[0].CheckName != "AccessCheckFailed" || got[0].Severity != codeowners.Error || got[0].Position != want ||
+		got[0].Cause == nil || got[0].Message != wantMessage+got[0].Cause.Error() {
+		t.Errorf("Input: %v, Want: %v %s..., Got: %v", input, want, wantMessage, got)
+	}
+}
+
 func TestAccessCheck(t *testing.T) {
 	input := struct {
 		lineNo int
@@ -25,7 +50,7 @@ func TestAccessCheck(t *testing.T) {
 				EndLine:     1,
 				EndColumn:   19,
 			},
-			Message:   "Owner '@owner' has no write access",
+			Message:   "Owner '@owner' has no write access to owner/repo",
 			Severity:  codeowners.Error,
 			CheckName: "Access",
 		},
@@ -51,19 +76,12 @@ func TestAccessCheckError(t *testing.T) {
 		lineNo: 1,
 		line:   "filepattern @ownerWithError",
 	}
-	want := []codeowners.CheckResult{
-		{
-			Position: codeowners.Position{
-				FilePath:    "CODEOWNERS",
-				StartLine:   1,
-				StartColumn: 13,
-				EndLine:     1,
-				EndColumn:   28,
-			},
-			Message:   "Owner '@ownerWithError' has no write access",
-			Severity:  codeowners.Error,
-			CheckName: "Access",
-		},
+	want := codeowners.Position{
+		FilePath:    "CODEOWNERS",
+		StartLine:   1,
+		StartColumn: 13,
+		EndLine:     1,
+		EndColumn:   28,
 	}
 
 	checker := checkers.Access{}
@@ -73,9 +91,7 @@ func TestAccessCheckError(t *testing.T) {
 		GithubToken:            "token",
 	})
 	got := validator.ValidateLine(input.lineNo, input.line)
-	if !reflect.DeepEqual(got, want) {
-		t.Errorf("Input: %v, Want: %v, Got: %v", input, want, got)
-	}
+	assertAccessCheckFailed(t, input, got, want, codeowners.Error, "@ownerWithError")
 }
 
 func TestAccessCheckPass(t *testing.T) {
@@ -184,30 +200,18 @@ func TestAccessCheckInvalidDir(t *testing.T) {
 		lineNo: 1,
 		line:   "filepattern @ownerWithAccess",
 	}
-	want := []codeowners.CheckResult{
-		{
-			Position: codeowners.Position{
-				FilePath:    "bad",
-				StartLine:   1,
-				StartColumn: 13,
-				EndLine:     1,
-				EndColumn:   29,
-			},
-			Message:   "Owner '@ownerWithAccess' has no write access",
-			Severity:  codeowners.Error,
-			CheckName: "Access",
-		},
+	want := codeowners.Position{
+		FilePath: "bad",
 	}
+
 	checker := checkers.Access{}
 	validator := checker.NewValidator(codeowners.ValidatorOptions{
 		Directory:              "bad",
 		CodeownersFileLocation: "bad",
 		GithubToken:            "token",
 	})
-	got := validator.ValidateLine(input.lineNo, input.line)
-	if !reflect.DeepEqual(got, want) {
-		t.Errorf("Input: %v, Want: %v, Got: %v", input, want, got)
-	}
+	got := validateFile(t, validator, input.line+"\nother @owner @ownerWithAccess\n")
+	assertSourceFailed(t, input, got, want)
 }
 
 func TestAccessCheckNoCollaborator(t *testing.T) {
@@ -227,7 +231,7 @@ func TestAccessCheckNoCollaborator(t *testing.T) {
 				EndLine:     1,
 				EndColumn:   21,
 			},
-			Message:   "Owner '@noOwner' has no write access",
+			Message:   "Owner '@noOwner' has no write access to owner/repo",
 			Severity:  codeowners.Error,
 			CheckName: "Access",
 		},
@@ -252,20 +256,14 @@ func TestAccessCheckIsCollaboratorError(t *testing.T) {
 		lineNo: 1,
 		line:   "filepattern @noOwnerWithError",
 	}
-	want := []codeowners.CheckResult{
-		{
-			Position: codeowners.Position{
-				FilePath:    "CODEOWNERS",
-				StartLine:   1,
-				StartColumn: 13,
-				EndLine:     1,
-				EndColumn:   30,
-			},
-			Message:   "Owner '@noOwnerWithError' has no write access",
-			Severity:  codeowners.Error,
-			CheckName: "Access",
-		},
+	want := codeowners.Position{
+		FilePath:    "CODEOWNERS",
+		StartLine:   1,
+		StartColumn: 13,
+		EndLine:     1,
+		EndColumn:   30,
 	}
+
 	checker := checkers.Access{}
 	validator := checker.NewValidator(codeowners.ValidatorOptions{
 		Directory:              ".",
@@ -273,9 +271,7 @@ func TestAccessCheckIsCollaboratorError(t *testing.T) {
 		GithubToken:            "token",
 	})
 	got := validator.ValidateLine(input.lineNo, input.line)
-	if !reflect.DeepEqual(got, want) {
-		t.Errorf("Input: %v, Want: %v, Got: %v", input, want, got)
-	}
+	assertAccessCheckFailed(t, input, got, want, codeowners.Error, "@noOwnerWithError")
 }
 
 func TestAccessCheckTeamPass(t *testing.T) {
@@ -315,7 +311,7 @@ func TestAccessCheckTeamDeny(t *testing.T) {
 				EndLine:     1,
 				EndColumn:   26,
 			},
-			Message:   "Owner '@org/denyTeam' has no write access",
+			Message:   "Owner '@org/denyTeam' has no write access to owner/repo",
 			Severity:  codeowners.Error,
 			CheckName: "Access",
 		},
@@ -368,7 +364,7 @@ func TestAccessCheckEmailDeny(t *testing.T) {
 				EndLine:     1,
 				EndColumn:   30,
 			},
-			Message:   "Owner 'found@example.com' has no write access",
+			Message:   "Owner 'found@example.com' has no write access to owner/repo",
 			Severity:  codeowners.Error,
 			CheckName: "Access",
 		},
@@ -408,7 +404,7 @@ func TestAccessCheckDenyMemo(t *testing.T) {
 				EndLine:     1,
 				EndColumn:   26,
 			},
-			Message:   "Owner '@org/denyTeam' has no write access",
+			Message:   "Owner '@org/denyTeam' has no write access to owner/repo",
 			Severity:  codeowners.Error,
 			CheckName: "Access",
 		},
@@ -420,7 +416,7 @@ func TestAccessCheckDenyMemo(t *testing.T) {
 				EndLine:     2,
 				EndColumn:   27,
 			},
-			Message:   "Owner '@org/denyTeam' has no write access",
+			Message:   "Owner '@org/denyTeam' has no write access to owner/repo",
 			Severity:  codeowners.Error,
 			CheckName: "Access",
 		},
@@ -440,3 +436,140 @@ func TestAccessCheckDenyMemo(t *testing.T) {
 		t.Errorf("Input: %v, Want: %v, Got: %v", input, want, got)
 	}
 }
+
+func TestAccessCheckFailuresOption(t *testing.T) {
+	input := struct {
+		lineNo int
+		line   string
+	}{
+		lineNo: 1,
+		line:   "filepattern @ownerWithError",
+	}
+	want := codeowners.Position{
+		FilePath:    "CODEOWNERS",
+		StartLine:   1,
+		StartColumn: 13,
+		EndLine:     1,
+		EndColumn:   28,
+	}
+
+	checker := checkers.Access{}
+	validator := checker.NewValidator(codeowners.ValidatorOptions{
+		Directory:              ".",
+		CodeownersFileLocation: "CODEOWNERS",
+		GithubToken:            "token",
+		Options:                map[string]string{checkers.AccessFailuresOption: "warning"},
+	})
+	got := validator.ValidateLine(input.lineNo, input.line)
+	assertAccessCheckFailed(t, input, got, want, codeowners.Warning, "@ownerWithError")
+
+	validator = checker.NewValidator(codeowners.ValidatorOptions{
+		Directory:              ".",
+		CodeownersFileLocation: "CODEOWNERS",
+		GithubToken:            "token",
+		Options:                map[string]string{checkers.AccessFailuresOption: "skip"},
+	})
+	got = validator.ValidateLine(input.lineNo, input.line)
+	if got != nil {
+		t.Errorf("Input: %v, Want: %v, Got: %v", input, nil, got)
+	}
+}
+
+func TestAccessValidateOptions(t *testing.T) {
+	tests := []struct {
+		failures string
+		wantErr  string
+	}{
+		{failures: ""},
+		{failures: "error"},
+		{failures: "Warning"},
+		{failures: "skip"},
+		{failures: "warn", wantErr: "Invalid failures 'warn', expected error, warning or skip"},
+	}
+	for _, test := range tests {
+		err := checkers.Access{}.ValidateOptions(map[string]string{checkers.AccessFailuresOption: test.failures})
+		if (err == nil && len(test.wantErr) > 0) || (err != nil && err.Error() != test.wantErr) {
+			t.Errorf("Input: %v, Want: %v, Got: %v", test.failures, test.wantErr, err)
+		}
+	}
+}
+
+func TestAccessCheckSnapshot(t *testing.T) {
+	tests := []struct {
+		line string
+		want []string
+	}{
+		{line: "filepattern @octocat @OctoCat", want: nil},
+		{line: "filepattern @reader", want: []string{"Owner '@reader' has no write access to owner/repo"}},
+		{line: "filepattern @unknown", want: []string{"Owner '@unknown' has no write access to owner/repo"}},
+		{line: "filepattern @github/justice-league", want: nil},
+		{line: "filepattern @owner/readers", want: []string{"Owner '@owner/readers' has no write access to owner/repo"}},
+		{line: "filepattern octocat@example.com unknown@example.com", want: nil},
+		{line: "filepattern reader@example.com", want: []string{"Owner 'reader@example.com' has no write access to owner/repo"}},
+	}
+
+	checker := checkers.Access{}
+	validator := checker.NewValidator(codeowners.ValidatorOptions{
+		Directory:              ".",
+		CodeownersFileLocation: "CODEOWNERS",
+		Options: map[string]string{
+			checkers.AccessSnapshotOption: "../test/fixtures/snapshot/snapshot.yaml",
+		},
+	})
+	for _, test := range tests {
+		var got []string
+		for _, result := range validator.ValidateLine(1, test.line) {
+			got = append(got, result.Message)
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("Input: %v, Want: %v, Got: %v", test.line, test.want, got)
+		}
+	}
+}
+
+func TestAccessCheckSnapshotOtherRepository(t *testing.T) {
+	tests := []struct {
+		platform   string
+		repository string
+		want       []string
+	}{
+		{repository: "", want: nil},
+		{repository: "Owner/Repo", want: nil},
+		{platform: "github", repository: "Owner/Repo", want: nil},
+		{repository: "other/repo", want: []string{"Could not check access of owners: Snapshot of owner/repo does not match the repository other/repo"}},
+		{platform: "gitlab", repository: "owner/repo", want: []string{"Could not check access of owners: Snapshots are only supported on Github, not on gitlab"}},
+		{platform: "gitea", want: []string{"Could not check access of owners: Snapshots are only supported on Github, not on gitea"}},
+	}
+	for _, test := range tests {
+		validator := checkers.Access{}.NewValidator(codeowners.ValidatorOptions{
+			Directory:              ".",
+			CodeownersFileLocation: "CODEOWNERS",
+			Platform:               test.platform,
+			Repository:             test.repository,
+			Options: map[string]string{
+				checkers.AccessSnapshotOption: "../test/fixtures/snapshot/snapshot.yaml",
+			},
+		})
+		var got []string
+		for _, result := range validateFile(t, validator, "filepattern @octocat\n") {
+			got = append(got, result.Message)
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("Input: %v, Want: %v, Got: %v", test.repository, test.want, got)
+		}
+	}
+}
+
+func TestAccessCheckSnapshotMissing(t *testing.T) {
+	input := "filepattern @octocat"
+	checker := checkers.Access{}
+	validator := checker.NewValidator(codeowners.ValidatorOptions{
+		Directory:              ".",
+		CodeownersFileLocation: "CODEOWNERS",
+		Options: map[string]string{
+			checkers.AccessSnapshotOption: "../test/fixtures/snapshot/missing.yaml",
+		},
+	})
+	got := validateFile(t, validator, input)
+	assertSourceFailed(t, input, got, codeowners.Position{FilePath: "CODEOWNERS"})
+}
@@ -0,0 +1,147 @@
+package checkers
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/google/go-github/v32/github"
+	"golang.org/x/oauth2"
+)
+
+// appJWTLifetime is how long the JWTs authenticating as the Github App are valid, Github allows up to 10 minutes
+const appJWTLifetime time.Duration = 9 * time.Minute
+
+// appClockDrift is how far in the past JWTs are issued, covering clocks running ahead of Github's
+const appClockDrift time.Duration = time.Minute
+
+// installationTokenRefreshMargin is how long before their expiry installation tokens are refreshed
+const installationTokenRefreshMargin time.Duration = 5 * time.Minute
+
+// loadPrivateKey reads the PEM encoded RSA private key of a Github App, in PKCS#1 or PKCS#8 form
+func loadPrivateKey(file string) (*rsa.PrivateKey, error) {
+	content, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(content)
+	if block == nil {
+		return nil, fmt.Errorf("No private key found in %s", file)
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid private key in %s: %v", file, err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("Private key in %s is not an RSA key", file)
+	}
+	return key, nil
+}
+
+// appJWT returns a JWT signed with RS256 authenticating as the Github App appID
+func appJWT(appID int64, key *rsa.PrivateKey, now time.Time) (string, error) {
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+	claims, err := json.Marshal(map[string]int64{
+		"iat": now.Add(-appClockDrift).Unix(),
+		"exp": now.Add(appJWTLifetime).Unix(),
+		"iss": appID,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	hash := sha256.Sum256([]byte(unsigned))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, hash[:])
+	if err != nil {
+		return "", err
+	}
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// appTransport authenticates requests as the Github App, it is only used to exchange installation tokens
+type appTransport struct {
+	base  http.RoundTripper
+	appID int64
+	key   *rsa.PrivateKey
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *appTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := appJWT(t.appID, t.key, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+token)
+	return t.base.RoundTrip(req)
+}
+
+// installationTokenSource exchanges the Github App JWT for installation tokens
+type installationTokenSource struct {
+	ctx            context.Context
+	client         *github.Client // client authenticates as the Github App
+	installationID int64
+}
+
+// Token implements oauth2.TokenSource, the token expires early so it is refreshed before Github rejects it
+func (s *installationTokenSource) Token() (*oauth2.Token, error) {
+	token, _, err := s.client.Apps.CreateInstallationToken(s.ctx, s.installationID, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(token.GetToken()) == 0 {
+		return nil, errors.New("Github returned an empty installation token")
+	}
+	return &oauth2.Token{
+		AccessToken: token.GetToken(),
+		TokenType:   "token",
+		Expiry:      token.GetExpiresAt().Add(-installationTokenRefreshMargin),
+	}, nil
+}
+
+// appTokenSource returns a token source of installation tokens for the configured Github App,
+// the installation of the repository is looked up when no installation ID is given
+func (a *accessAPI) appTokenSource(transport http.RoundTripper, baseURL, uploadURL string) (oauth2.TokenSource, error) {
+	key, err := loadPrivateKey(a.appKeyFile)
+	if err != nil {
+		return nil, err
+	}
+	appClient, err := newGithubClient(&http.Client{Transport: &appTransport{base: transport, appID: a.appID, key: key}}, baseURL, uploadURL)
+	if err != nil {
+		return nil, err
+	}
+
+	installationID := a.appInstallationID
+	if installationID == 0 {
+		installation, _, err := appClient.Apps.FindRepositoryInstallation(a.ctx, a.repoOwner, a.repoName)
+		if err != nil {
+			return nil, fmt.Errorf("Could not find the Github App installation of %s: %v", a.Project(), err)
+		}
+		installationID = installation.GetID()
+	}
+
+	return oauth2.ReuseTokenSource(nil, &installationTokenSource{
+		ctx:            a.ctx,
+		client:         appClient,
+		installationID: installationID,
+	}), nil
+}
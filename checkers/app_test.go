@@ -0,0 +1,155 @@
+// +build unit
+
+package checkers
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/fmenezes/codeowners"
+)
+
+func generateKeyFile(t *testing.T) (*rsa.PrivateKey, string) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key, writeTempFile(t, pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(key),
+	}))
+}
+
+func TestLoadPrivateKey(t *testing.T) {
+	key, pkcs1File := generateKeyFile(t)
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkcs8File := writeTempFile(t, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8}))
+
+	for _, input := range []string{pkcs1File, pkcs8File} {
+		got, err := loadPrivateKey(input)
+		if err != nil || got.D.Cmp(key.D) != 0 {
+			t.Errorf("Input: %v, Want: %v, Got: %v", input, "the generated key", err)
+		}
+	}
+
+	invalid := []string{
+		filepath.Join("does", "not", "exist.pem"),
+		writeTempFile(t, []byte("not a key")),
+		writeTempFile(t, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: []byte("garbage")})),
+	}
+	for _, input := range invalid {
+		_, err := loadPrivateKey(input)
+		if err == nil {
+			t.Errorf("Input: %v, Want: error, Got: %v", input, err)
+		}
+	}
+}
+
+func TestAppJWT(t *testing.T) {
+	key, _ := generateKeyFile(t)
+	mockAppKey = key
+	now := time.Unix(1600000000, 0)
+
+	token, err := appJWT(1234, key, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !validAppJWT("Bearer " + token) {
+		t.Errorf("Input: %v, Want: valid signature, Got: %v", token, "invalid signature")
+	}
+
+	claims, err := base64.RawURLEncoding.DecodeString(strings.Split(token, ".")[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := map[string]int64{}
+	err = json.Unmarshal(claims, &got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]int64{"iat": 1599999940, "exp": 1600000540, "iss": 1234}
+	for name, value := range want {
+		if got[name] != value {
+			t.Errorf("Claim: %s, Want: %v, Got: %v", name, value, got[name])
+		}
+	}
+}
+
+func TestAccessGithubApp(t *testing.T) {
+	key, keyFile := generateKeyFile(t)
+	mockAppKey = key
+
+	validator := Access{}.NewValidator(codeowners.ValidatorOptions{
+		Directory:               ".",
+		CodeownersFileLocation:  "CODEOWNERS",
+		GithubAppID:             1234,
+		GithubAppPrivateKeyFile: keyFile,
+	})
+	got := validator.ValidateLine(1, "filepattern @ownerWithAccess")
+	if got != nil {
+		t.Errorf("Want: %v, Got: %v", nil, got)
+	}
+	if authorization := lastAuthorization.Load(); authorization != "token ghs_mocked" {
+		t.Errorf("Want: %v, Got: %v", "token ghs_mocked", authorization)
+	}
+}
+
+func TestAccessGithubAppUnknownKey(t *testing.T) {
+	key, _ := generateKeyFile(t)
+	mockAppKey = key
+	_, keyFile := generateKeyFile(t)
+
+	validator := Access{}.NewValidator(codeowners.ValidatorOptions{
+		Directory:               ".",
+		CodeownersFileLocation:  "CODEOWNERS",
+		GithubAppID:             1234,
+		GithubAppPrivateKeyFile: keyFile,
+	}).(*accessValidator)
+	got := append(validator.ValidateLine(1, "filepattern @ownerWithAccess"), validator.Finish()...)
+	if len(got) != 1 || got[0].CheckName != "AccessCheckFailed" {
+		t.Errorf("Want: %v, Got: %v", "AccessCheckFailed", got)
+	}
+}
+
+func TestInstallationTokenRefresh(t *testing.T) {
+	key, keyFile := generateKeyFile(t)
+	mockAppKey = key
+
+	tests := []struct {
+		installationID int64
+		want           int32
+	}{
+		{installationID: 42, want: 1}, // valid for an hour, reused
+		{installationID: 43, want: 4}, // expires within the refresh margin, refreshed for every request
+	}
+	for _, test := range tests {
+		api, err := newAccessAPI(codeowners.ValidatorOptions{
+			Directory:               ".",
+			GithubAppID:             1234,
+			GithubAppPrivateKeyFile: keyFile,
+			GithubAppInstallationID: test.installationID,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		atomic.StoreInt32(&tokenExchanges, 0)
+		resolverSource{api}.ownerHasWriteAccess("@ownerWithAccess")
+		resolverSource{api}.ownerHasWriteAccess("@owner")
+		if got := atomic.LoadInt32(&tokenExchanges); got != test.want {
+			t.Errorf("Input: %v, Want: %v, Got: %v", test.installationID, test.want, got)
+		}
+	}
+}
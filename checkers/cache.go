@@ -0,0 +1,105 @@
+package checkers
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// DefaultAccessCacheTTL is how long cached access lookups are trusted when AccessCacheTTLOption is not set
+const DefaultAccessCacheTTL time.Duration = time.Hour
+
+// accessCache keeps the access of owners on disk between runs, one file per host, repository and owner
+type accessCache struct {
+	dir string
+	ttl time.Duration
+	now func() time.Time
+}
+
+type accessCacheEntry struct {
+	WriteAccess bool      `json:"writeAccess"`
+	CheckedAt   time.Time `json:"checkedAt"`
+}
+
+func newAccessCache(dir string, ttl time.Duration) *accessCache {
+	return &accessCache{dir: dir, ttl: ttl, now: time.Now}
+}
+
+// path returns the file of owner in repository on host, names are escaped so teams and emails are single file names
+func (c *accessCache) path(host, repository, owner string) string {
+	return filepath.Join(c.dir, url.PathEscape(host), filepath.FromSlash(repository), url.PathEscape(owner)+".json")
+}
+
+// get returns the cached access of owner, entries older than the TTL or unreadable are missing
+func (c *accessCache) get(host, repository, owner string) (bool, bool) {
+	content, err := ioutil.ReadFile(c.path(host, repository, owner))
+	if err != nil {
+		return false, false
+	}
+	entry := accessCacheEntry{}
+	if err = json.Unmarshal(content, &entry); err != nil {
+		return false, false
+	}
+	if c.now().Sub(entry.CheckedAt) > c.ttl {
+		return false, false
+	}
+	return entry.WriteAccess, true
+}
+
+// set stores the access of owner, the file is replaced atomically
+func (c *accessCache) set(host, repository, owner string, writeAccess bool) error {
+	file := c.path(host, repository, owner)
+	err := os.MkdirAll(filepath.Dir(file), 0755)
+	if err != nil {
+		return err
+	}
+	content, err := json.Marshal(accessCacheEntry{WriteAccess: writeAccess, CheckedAt: c.now().UTC()})
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(file, content)
+}
+
+// writeFileAtomic replaces file with content through a temporary file, so concurrent runs never read partial files
+func writeFileAtomic(file string, content []byte) error {
+	tmp, err := ioutil.TempFile(filepath.Dir(file), ".tmp-")
+	if err != nil {
+		return err
+	}
+	_, err = tmp.Write(content)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), file)
+}
+
+// cachedSource answers from the cache when it has a recent entry and from source otherwise
+type cachedSource struct {
+	source accessSource
+	host   string
+	cache  *accessCache
+}
+
+func (c *cachedSource) repository() string {
+	return c.source.repository()
+}
+
+func (c *cachedSource) ownerHasWriteAccess(owner string) (bool, error) {
+	writeAccess, found := c.cache.get(c.host, c.source.repository(), owner)
+	if found {
+		return writeAccess, nil
+	}
+	writeAccess, err := c.source.ownerHasWriteAccess(owner)
+	if err != nil {
+		return false, err
+	}
+	c.cache.set(c.host, c.source.repository(), owner, writeAccess) // a cache that cannot be written only costs a lookup next run
+	return writeAccess, nil
+}
@@ -0,0 +1,73 @@
+package checkers
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestAccessCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "codeowners")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	now := time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC)
+	cache := newAccessCache(dir, time.Hour)
+	cache.now = func() time.Time { return now }
+
+	owners := map[string]bool{"@owner": true, "@org/team": false, "user@example.com": true}
+	for owner, writeAccess := range owners {
+		err = cache.set("github.com", "owner/repo", owner, writeAccess)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	for owner, want := range owners {
+		got, found := cache.get("github.com", "owner/repo", owner)
+		if !found || got != want {
+			t.Errorf("Input: %v, Want: %v, Got: %v %v", owner, want, got, found)
+		}
+	}
+
+	if _, err = os.Stat(filepath.Join(dir, "github.com", "owner", "repo", "@org%2Fteam.json")); err != nil {
+		t.Errorf("Input: %v, Want: a single file per owner, Got: %v", "@org/team", err)
+	}
+	if _, found := cache.get("ghe.example.com", "owner/repo", "@owner"); found {
+		t.Errorf("Input: %v, Want: missing on other hosts, Got: %v", "@owner", found)
+	}
+	if _, found := cache.get("github.com", "owner/other", "@owner"); found {
+		t.Errorf("Input: %v, Want: missing on other repositories, Got: %v", "@owner", found)
+	}
+
+	now = now.Add(2 * time.Hour)
+	if _, found := cache.get("github.com", "owner/repo", "@owner"); found {
+		t.Errorf("Input: %v, Want: expired, Got: %v", "@owner", found)
+	}
+}
+
+func TestAccessCacheCorruptEntry(t *testing.T) {
+	dir, err := ioutil.TempDir("", "codeowners")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	cache := newAccessCache(dir, time.Hour)
+	file := cache.path("github.com", "owner/repo", "@owner")
+	err = os.MkdirAll(filepath.Dir(file), 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ioutil.WriteFile(file, []byte("{"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, found := cache.get("github.com", "owner/repo", "@owner"); found {
+		t.Errorf("Input: %v, Want: missing, Got: %v", "{", found)
+	}
+}
@@ -0,0 +1,67 @@
+package checkers
+
+import (
+	"fmt"
+
+	"github.com/fmenezes/codeowners"
+)
+
+const duplicateOwnerCheckerName string = "DuplicateOwner"
+
+func init() {
+	codeowners.RegisterChecker(duplicateOwnerCheckerName, DuplicateOwner{})
+}
+
+// DuplicateOwner represents checker to find owners listed more than once in the same CODEOWNERS line
+type DuplicateOwner struct{}
+
+// Describe returns metadata about this checker
+func (c DuplicateOwner) Describe() codeowners.CheckerInfo {
+	return codeowners.CheckerInfo{
+		Description: "Owners must not be listed more than once in the same line",
+		Severity:    codeowners.Warning,
+	}
+}
+
+// NewValidator returns validating capabilities for this checker
+func (c DuplicateOwner) NewValidator(options codeowners.ValidatorOptions) codeowners.Validator {
+	return duplicateOwnerValidator{
+		options: options,
+	}
+}
+
+type duplicateOwnerValidator struct {
+	options codeowners.ValidatorOptions
+}
+
+// ValidateLine runs this DuplicateOwner's check against each line
+func (v duplicateOwnerValidator) ValidateLine(lineNo int, line string) []codeowners.CheckResult {
+	var results []codeowners.CheckResult
+
+	node := codeowners.ParseDialectNode(lineNo, line, codeowners.PlatformDialect(v.options.Platform))
+
+	seen := make(map[string]bool)
+	for i, owner := range node.Owners {
+		if !seen[owner.Value] {
+			seen[owner.Value] = true
+			continue
+		}
+
+		// removes the owner along with the white space separating it from the previous field
+		removal := owner.Span.Position(v.options.CodeownersFileLocation)
+		removal.StartColumn = node.Owners[i-1].Span.EndColumn
+
+		results = append(results, codeowners.CheckResult{
+			Position:  owner.Span.Position(v.options.CodeownersFileLocation),
+			Message:   fmt.Sprintf("Owner '%s' is listed more than once", owner.Value),
+			Severity:  codeowners.Warning,
+			CheckName: duplicateOwnerCheckerName,
+			SuggestedFix: &codeowners.SuggestedFix{
+				Message: fmt.Sprintf("Remove duplicate owner '%s'", owner.Value),
+				Edits:   []codeowners.TextEdit{codeowners.ReplaceSpan(removal, "")},
+			},
+		})
+	}
+
+	return results
+}
@@ -0,0 +1,77 @@
+package checkers_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/fmenezes/codeowners"
+	"github.com/fmenezes/codeowners/checkers"
+)
+
+func TestDuplicateOwnerCheck(t *testing.T) {
+	input := struct {
+		lineNo int
+		line   string
+	}{
+		lineNo: 1,
+		line:   "filepattern @owner @other  @owner",
+	}
+	want := []codeowners.CheckResult{
+		{
+			Position: codeowners.Position{
+				FilePath:    "CODEOWNERS",
+				StartLine:   1,
+				StartColumn: 28,
+				EndLine:     1,
+				EndColumn:   34,
+			},
+			Message:   "Owner '@owner' is listed more than once",
+			Severity:  codeowners.Warning,
+			CheckName: "DuplicateOwner",
+			SuggestedFix: &codeowners.SuggestedFix{
+				Message: "Remove duplicate owner '@owner'",
+				Edits: []codeowners.TextEdit{
+					{
+						Position: codeowners.Position{
+							FilePath:    "CODEOWNERS",
+							StartLine:   1,
+							StartColumn: 26,
+							EndLine:     1,
+							EndColumn:   34,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	checker := checkers.DuplicateOwner{}
+	validator := checker.NewValidator(codeowners.ValidatorOptions{
+		Directory:              ".",
+		CodeownersFileLocation: "CODEOWNERS",
+	})
+	got := validator.ValidateLine(input.lineNo, input.line)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Input: %v, Want: %v, Got: %v", input, want, got)
+	}
+}
+
+func TestDuplicateOwnerCheckPass(t *testing.T) {
+	input := struct {
+		lineNo int
+		line   string
+	}{
+		lineNo: 1,
+		line:   "filepattern @owner @other # @owner",
+	}
+
+	checker := checkers.DuplicateOwner{}
+	validator := checker.NewValidator(codeowners.ValidatorOptions{
+		Directory:              ".",
+		CodeownersFileLocation: "CODEOWNERS",
+	})
+	got := validator.ValidateLine(input.lineNo, input.line)
+	if got != nil {
+		t.Errorf("Input: %v, Want: %v, Got: %v", input, nil, got)
+	}
+}
@@ -0,0 +1,92 @@
+package checkers
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fmenezes/codeowners"
+)
+
+const duplicatePatternCheckerName string = "DuplicatePattern"
+
+func init() {
+	codeowners.RegisterChecker(duplicatePatternCheckerName, DuplicatePattern{})
+}
+
+// DuplicatePattern represents checker to find patterns declared more than once, as only the last declaration is ever used.
+// In dialects combining matches, such as Gitea's, every declaration is used and the later ones are reported instead.
+// In dialects with sections, such as GitLab's, a pattern is only a duplicate of the declarations within the same section.
+type DuplicatePattern struct{}
+
+// Describe returns metadata about this checker
+func (c DuplicatePattern) Describe() codeowners.CheckerInfo {
+	return codeowners.CheckerInfo{
+		Description: "Patterns must not be declared more than once",
+		Severity:    codeowners.Warning,
+	}
+}
+
+// NewValidator returns validating capabilities for this checker
+func (c DuplicatePattern) NewValidator(options codeowners.ValidatorOptions) codeowners.Validator {
+	return &duplicatePatternValidator{
+		options: options,
+		dialect: codeowners.PlatformDialect(options.Platform),
+	}
+}
+
+type duplicatePatternValidator struct {
+	options  codeowners.ValidatorOptions
+	dialect  codeowners.Dialect
+	declared map[string]codeowners.Node
+}
+
+// ValidateLine is a no-op, this checker validates rules
+func (v *duplicatePatternValidator) ValidateLine(lineNo int, line string) []codeowners.CheckResult {
+	return nil
+}
+
+// Begin resets the declared patterns
+func (v *duplicatePatternValidator) Begin(file *codeowners.File) {
+	v.declared = make(map[string]codeowners.Node)
+}
+
+// ValidateRule reports the previous declaration of the rule's pattern, as this rule always takes precedence
+func (v *duplicatePatternValidator) ValidateRule(rule codeowners.Node) []codeowners.CheckResult {
+	key := rule.Pattern.Value
+	if rule.Section != nil {
+		key = strings.ToLower(rule.Section.Name.Value) + "]" + key
+	}
+	previous, found := v.declared[key]
+	if found && v.dialect.CombinesMatches() {
+		return []codeowners.CheckResult{
+			{
+				Position:  rule.Pattern.Span.Position(v.options.CodeownersFileLocation),
+				Message:   fmt.Sprintf("Pattern '%s' is already declared on line %d, the owners of both are combined", rule.Pattern.Value, previous.Span.Line),
+				Severity:  codeowners.Warning,
+				CheckName: duplicatePatternCheckerName,
+			},
+		}
+	}
+	v.declared[key] = rule
+	if !found {
+		return nil
+	}
+
+	return []codeowners.CheckResult{
+		{
+			Position:  previous.Pattern.Span.Position(v.options.CodeownersFileLocation),
+			Message:   fmt.Sprintf("Pattern '%s' is overridden by line %d", previous.Pattern.Value, rule.Span.Line),
+			Severity:  codeowners.Warning,
+			CheckName: duplicatePatternCheckerName,
+			SuggestedFix: &codeowners.SuggestedFix{
+				Message: "Remove overridden rule",
+				Edits:   []codeowners.TextEdit{codeowners.DeleteLine(v.options.CodeownersFileLocation, previous.Span.Line)},
+			},
+		},
+	}
+}
+
+// Finish has nothing left to report
+func (v *duplicatePatternValidator) Finish() []codeowners.CheckResult {
+	return nil
+}
@@ -0,0 +1,151 @@
+package checkers_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/fmenezes/codeowners"
+	"github.com/fmenezes/codeowners/checkers"
+)
+
+func validateFile(t *testing.T, validator codeowners.Validator, input string) []codeowners.CheckResult {
+	return validateDialectFile(t, validator, input, codeowners.GithubDialect)
+}
+
+func validateDialectFile(t *testing.T, validator codeowners.Validator, input string, dialect codeowners.Dialect) []codeowners.CheckResult {
+	file, err := codeowners.ParseDialect(strings.NewReader(input), dialect)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fileValidator := validator.(codeowners.FileValidator)
+
+	var results []codeowners.CheckResult
+	fileValidator.Begin(file)
+	for _, node := range file.Nodes {
+		results = append(results, validator.ValidateLine(node.Span.Line, node.Raw)...)
+		if node.Kind == codeowners.RuleNode {
+			results = append(results, fileValidator.ValidateRule(node)...)
+		}
+	}
+	return append(results, fileValidator.Finish()...)
+}
+
+func TestDuplicatePatternCheck(t *testing.T) {
+	input := `* @owner
+docs/ @writers
+# comment
+docs/ @editors
+`
+	want := []codeowners.CheckResult{
+		{
+			Position: codeowners.Position{
+				FilePath:    "CODEOWNERS",
+				StartLine:   2,
+				StartColumn: 1,
+				EndLine:     2,
+				EndColumn:   6,
+			},
+			Message:   "Pattern 'docs/' is overridden by line 4",
+			Severity:  codeowners.Warning,
+			CheckName: "DuplicatePattern",
+			SuggestedFix: &codeowners.SuggestedFix{
+				Message: "Remove overridden rule",
+				Edits:   []codeowners.TextEdit{codeowners.DeleteLine("CODEOWNERS", 2)},
+			},
+		},
+	}
+
+	checker := checkers.DuplicatePattern{}
+	validator := checker.NewValidator(codeowners.ValidatorOptions{
+		Directory:              ".",
+		CodeownersFileLocation: "CODEOWNERS",
+	})
+	got := validateFile(t, validator, input)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Input: %v, Want: %v, Got: %v", input, want, got)
+	}
+}
+
+func TestDuplicatePatternCheckPass(t *testing.T) {
+	input := `* @owner
+docs/ @writers
+/docs/ @editors
+`
+	checker := checkers.DuplicatePattern{}
+	validator := checker.NewValidator(codeowners.ValidatorOptions{
+		Directory:              ".",
+		CodeownersFileLocation: "CODEOWNERS",
+	})
+	got := validateFile(t, validator, input)
+	if got != nil {
+		t.Errorf("Input: %v, Want: %v, Got: %v", input, nil, got)
+	}
+}
+
+func TestDuplicatePatternCheckCombined(t *testing.T) {
+	input := `.* @owner
+docs/.* @writers
+docs/.* @editors
+docs/.* @reviewers
+`
+	want := []codeowners.CheckResult{
+		{
+			Position:  codeowners.Position{FilePath: "CODEOWNERS", StartLine: 3, StartColumn: 1, EndLine: 3, EndColumn: 8},
+			Message:   "Pattern 'docs/.*' is already declared on line 2, the owners of both are combined",
+			Severity:  codeowners.Warning,
+			CheckName: "DuplicatePattern",
+		},
+		{
+			Position:  codeowners.Position{FilePath: "CODEOWNERS", StartLine: 4, StartColumn: 1, EndLine: 4, EndColumn: 8},
+			Message:   "Pattern 'docs/.*' is already declared on line 2, the owners of both are combined",
+			Severity:  codeowners.Warning,
+			CheckName: "DuplicatePattern",
+		},
+	}
+
+	checker := checkers.DuplicatePattern{}
+	validator := checker.NewValidator(codeowners.ValidatorOptions{
+		Directory:              ".",
+		CodeownersFileLocation: "CODEOWNERS",
+		Platform:               codeowners.GiteaPlatform,
+	})
+	got := validateFile(t, validator, input)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Input: %v, Want: %v, Got: %v", input, want, got)
+	}
+}
+
+func TestDuplicatePatternCheckSections(t *testing.T) {
+	input := `docs/ @owner
+
+[Documentation]
+docs/ @writers
+
+[documentation]
+docs/ @editors
+`
+	want := []codeowners.CheckResult{
+		{
+			Position:  codeowners.Position{FilePath: "CODEOWNERS", StartLine: 4, StartColumn: 1, EndLine: 4, EndColumn: 6},
+			Message:   "Pattern 'docs/' is overridden by line 7",
+			Severity:  codeowners.Warning,
+			CheckName: "DuplicatePattern",
+			SuggestedFix: &codeowners.SuggestedFix{
+				Message: "Remove overridden rule",
+				Edits:   []codeowners.TextEdit{codeowners.DeleteLine("CODEOWNERS", 4)},
+			},
+		},
+	}
+
+	checker := checkers.DuplicatePattern{}
+	validator := checker.NewValidator(codeowners.ValidatorOptions{
+		Directory:              ".",
+		CodeownersFileLocation: "CODEOWNERS",
+		Platform:               codeowners.GitlabPlatform,
+	})
+	got := validateDialectFile(t, validator, input, codeowners.GitlabDialect)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Input: %v, Want: %v, Got: %v", input, want, got)
+	}
+}
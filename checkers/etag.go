@@ -0,0 +1,164 @@
+package checkers
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// CachedResponse is a response kept to answer conditional requests
+type CachedResponse struct {
+	ETag   string
+	Header http.Header
+	Body   []byte
+}
+
+// ETagStore keeps responses by request, so resources that did not change are served without spending rate limit
+type ETagStore interface {
+	Get(key string) (CachedResponse, bool)
+	Set(key string, response CachedResponse)
+}
+
+type memoryETagStore struct {
+	mutex     sync.Mutex
+	responses map[string]CachedResponse
+}
+
+// NewMemoryETagStore returns an ETagStore kept in memory, it is safe for concurrent use
+func NewMemoryETagStore() ETagStore {
+	return &memoryETagStore{responses: make(map[string]CachedResponse)}
+}
+
+// Get returns the response stored for key
+func (s *memoryETagStore) Get(key string) (CachedResponse, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	response, found := s.responses[key]
+	return response, found
+}
+
+// Set stores response for key
+func (s *memoryETagStore) Set(key string, response CachedResponse) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.responses[key] = response
+}
+
+// etagCacheDir is the directory of the access cache where responses are kept, host names never start with a dot
+const etagCacheDir string = ".etags"
+
+type fileETagStore struct {
+	dir string
+}
+
+// NewFileETagStore returns an ETagStore keeping one file per request in dir, so responses are reused across runs.
+// Responses are always revalidated, so they never expire. A store that cannot be read or written only costs full requests.
+func NewFileETagStore(dir string) ETagStore {
+	return fileETagStore{dir: dir}
+}
+
+// path returns the file of key, keys are hashed as they hold URLs
+func (s fileETagStore) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(s.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// Get returns the response stored for key
+func (s fileETagStore) Get(key string) (CachedResponse, bool) {
+	content, err := ioutil.ReadFile(s.path(key))
+	if err != nil {
+		return CachedResponse{}, false
+	}
+	response := CachedResponse{}
+	if err = json.Unmarshal(content, &response); err != nil || len(response.ETag) == 0 {
+		return CachedResponse{}, false
+	}
+	return response, true
+}
+
+// Set stores response for key
+func (s fileETagStore) Set(key string, response CachedResponse) {
+	content, err := json.Marshal(response)
+	if err != nil {
+		return
+	}
+	if err = os.MkdirAll(s.dir, 0755); err != nil {
+		return
+	}
+	writeFileAtomic(s.path(key), content)
+}
+
+// defaultETagStore is shared by every accessAPI without a cache directory, so repeated checks in the same process reuse responses
+var defaultETagStore = NewMemoryETagStore()
+
+// etagTransport sends GET requests as conditional requests when a previous response is stored,
+// Github does not count 304 Not Modified responses against the rate limit
+type etagTransport struct {
+	base  http.RoundTripper
+	store ETagStore
+}
+
+func newETagTransport(base http.RoundTripper, store ETagStore) *etagTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &etagTransport{base: base, store: store}
+}
+
+// etagKey identifies a request, credentials are hashed so different tokens do not share responses
+func etagKey(req *http.Request) string {
+	credentials := sha256.Sum256([]byte(req.Header.Get("Authorization")))
+	return req.URL.String() + " " + hex.EncodeToString(credentials[:8])
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *etagTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet || t.store == nil {
+		return t.base.RoundTrip(req)
+	}
+
+	key := etagKey(req)
+	cached, found := t.store.Get(key)
+	if found {
+		req = req.Clone(req.Context())
+		req.Header.Set("If-None-Match", cached.ETag)
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if found && resp.StatusCode == http.StatusNotModified {
+		resp.Body.Close()
+		header := cached.Header.Clone()
+		for name, values := range resp.Header {
+			header[name] = values // keeps rate limit headers current
+		}
+		resp.StatusCode = http.StatusOK
+		resp.Status = "200 OK"
+		resp.Header = header
+		resp.Body = ioutil.NopCloser(bytes.NewReader(cached.Body))
+		resp.ContentLength = int64(len(cached.Body))
+		return resp, nil
+	}
+
+	etag := resp.Header.Get("ETag")
+	if resp.StatusCode != http.StatusOK || len(etag) == 0 {
+		return resp, nil
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	t.store.Set(key, CachedResponse{ETag: etag, Header: resp.Header.Clone(), Body: body})
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	return resp, nil
+}
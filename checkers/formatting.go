@@ -0,0 +1,97 @@
+package checkers
+
+import (
+	"github.com/fmenezes/codeowners"
+)
+
+const formattingCheckerName string = "Formatting"
+
+func init() {
+	codeowners.RegisterChecker(formattingCheckerName, Formatting{})
+}
+
+// Formatting represents checker to find CODEOWNERS lines not following the canonical format, see codeowners.Format
+type Formatting struct{}
+
+// Describe returns metadata about this checker
+func (c Formatting) Describe() codeowners.CheckerInfo {
+	return codeowners.CheckerInfo{
+		Description: "Lines must follow the canonical format",
+		Severity:    codeowners.Warning,
+	}
+}
+
+// NewValidator returns validating capabilities for this checker
+func (c Formatting) NewValidator(options codeowners.ValidatorOptions) codeowners.Validator {
+	return &formattingValidator{
+		options: options,
+	}
+}
+
+type formattingValidator struct {
+	options codeowners.ValidatorOptions
+	results []codeowners.CheckResult
+}
+
+// Begin compares every line of the file with its canonical form, see codeowners.Format.
+// Blank lines the canonical form drops are deleted, other lines are replaced by their canonical line.
+func (v *formattingValidator) Begin(file *codeowners.File) {
+	formatted := codeowners.Format(file).Nodes
+	next := 0
+	for _, node := range file.Nodes {
+		if node.Kind == codeowners.BlankNode && (next >= len(formatted) || formatted[next].Kind != codeowners.BlankNode) {
+			v.addResult(node.Span.Line, codeowners.DeleteLine(v.options.CodeownersFileLocation, node.Span.Line))
+			continue
+		}
+		if next >= len(formatted) {
+			return
+		}
+		want := formatted[next]
+		next++
+		if node.Raw == want.Raw && node.EOL == want.EOL {
+			continue
+		}
+
+		position := node.Span.Position(v.options.CodeownersFileLocation)
+		newText := want.Raw
+		if node.EOL != want.EOL {
+			newText += want.EOL
+			if len(node.EOL) > 0 {
+				position.EndLine, position.EndColumn = position.EndLine+1, 1
+			}
+		}
+		v.addResult(node.Span.Line, codeowners.ReplaceSpan(position, newText))
+	}
+}
+
+func (v *formattingValidator) addResult(lineNo int, edit codeowners.TextEdit) {
+	v.results = append(v.results, codeowners.CheckResult{
+		Position: codeowners.Position{
+			FilePath:  v.options.CodeownersFileLocation,
+			StartLine: lineNo,
+			EndLine:   lineNo,
+		},
+		Message:   "Line is not formatted",
+		Severity:  codeowners.Warning,
+		CheckName: formattingCheckerName,
+		SuggestedFix: &codeowners.SuggestedFix{
+			Message: "Format line",
+			Edits:   []codeowners.TextEdit{edit},
+		},
+	})
+}
+
+// ValidateLine does nothing, lines are compared with their canonical form in Begin
+func (v *formattingValidator) ValidateLine(lineNo int, line string) []codeowners.CheckResult {
+	return nil
+}
+
+// ValidateRule does nothing, lines are compared with their canonical form in Begin
+func (v *formattingValidator) ValidateRule(node codeowners.Node) []codeowners.CheckResult {
+	return nil
+}
+
+// Finish reports the lines not following the canonical form
+func (v *formattingValidator) Finish() []codeowners.CheckResult {
+	return v.results
+}
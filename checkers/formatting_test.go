@@ -0,0 +1,123 @@
+package checkers_test
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/fmenezes/codeowners"
+	"github.com/fmenezes/codeowners/checkers"
+)
+
+func formattingResult(lineNo int, edit codeowners.TextEdit) codeowners.CheckResult {
+	return codeowners.CheckResult{
+		Position: codeowners.Position{
+			FilePath:  "CODEOWNERS",
+			StartLine: lineNo,
+			EndLine:   lineNo,
+		},
+		Message:   "Line is not formatted",
+		Severity:  codeowners.Warning,
+		CheckName: "Formatting",
+		SuggestedFix: &codeowners.SuggestedFix{
+			Message: "Format line",
+			Edits:   []codeowners.TextEdit{edit},
+		},
+	}
+}
+
+func replaceLine(lineNo int, endLine int, endColumn int, newText string) codeowners.TextEdit {
+	return codeowners.ReplaceSpan(codeowners.Position{
+		FilePath:    "CODEOWNERS",
+		StartLine:   lineNo,
+		StartColumn: 1,
+		EndLine:     endLine,
+		EndColumn:   endColumn,
+	}, newText)
+}
+
+func TestFormattingCheck(t *testing.T) {
+	testCases := []struct {
+		input string
+		want  []codeowners.CheckResult
+	}{
+		{input: "  filepattern @owner\n", want: []codeowners.CheckResult{formattingResult(1, replaceLine(1, 1, 21, "filepattern @owner"))}},
+		{input: "filepattern @owner  \n", want: []codeowners.CheckResult{formattingResult(1, replaceLine(1, 1, 21, "filepattern @owner"))}},
+		{input: "filepattern\t@owner\n", want: []codeowners.CheckResult{formattingResult(1, replaceLine(1, 1, 19, "filepattern @owner"))}},
+		{input: "filepattern @owner   @other\n", want: []codeowners.CheckResult{formattingResult(1, replaceLine(1, 1, 28, "filepattern @owner @other"))}},
+		{input: "filepattern @owner    # comment  \n", want: []codeowners.CheckResult{formattingResult(1, replaceLine(1, 1, 34, "filepattern @owner # comment"))}},
+		{input: "   # comment\n", want: []codeowners.CheckResult{formattingResult(1, replaceLine(1, 1, 13, "# comment"))}},
+		{input: "a @x\nbbbbbb @y\n", want: []codeowners.CheckResult{formattingResult(1, replaceLine(1, 1, 5, "a      @x"))}},
+		{input: "a @x\r\n", want: []codeowners.CheckResult{formattingResult(1, replaceLine(1, 2, 1, "a @x\n"))}},
+		{input: "a @x", want: []codeowners.CheckResult{formattingResult(1, replaceLine(1, 1, 5, "a @x\n"))}},
+		{input: "   \na @x\n", want: []codeowners.CheckResult{formattingResult(1, codeowners.DeleteLine("CODEOWNERS", 1))}},
+		{input: "a @x\n\n\nb @y\n", want: []codeowners.CheckResult{formattingResult(3, codeowners.DeleteLine("CODEOWNERS", 3))}},
+	}
+
+	for _, testCase := range testCases {
+		validator := checkers.Formatting{}.NewValidator(codeowners.ValidatorOptions{
+			Directory:              ".",
+			CodeownersFileLocation: "CODEOWNERS",
+		})
+		got := validateFile(t, validator, testCase.input)
+		if !reflect.DeepEqual(got, testCase.want) {
+			t.Errorf("Input: %q, Want: %v, Got: %v", testCase.input, testCase.want, got)
+		}
+	}
+}
+
+func TestFormattingCheckPass(t *testing.T) {
+	testCases := []string{
+		"filepattern @owner\n",
+		"file @owner @other # comment\n",
+		"a      @x\nbbbbbb @y\n\nc @z\n",
+		"# comment\n",
+		"",
+		"filepattern\n",
+		"\\#file @a\n",
+	}
+
+	for _, input := range testCases {
+		validator := checkers.Formatting{}.NewValidator(codeowners.ValidatorOptions{
+			Directory:              ".",
+			CodeownersFileLocation: "CODEOWNERS",
+		})
+		got := validateFile(t, validator, input)
+		if got != nil {
+			t.Errorf("Input: %q, Want: %v, Got: %v", input, nil, got)
+		}
+	}
+}
+
+func TestFormattingFixesMatchFormat(t *testing.T) {
+	testCases := []string{
+		"a @x\nbbbbbb @y\n",
+		"\n\n  a\t@x   @y  # comment \r\n\n\n\nb\t\t@z\n\n",
+		"\\#file @a\nother\t@b",
+	}
+
+	for _, input := range testCases {
+		validator := checkers.Formatting{}.NewValidator(codeowners.ValidatorOptions{
+			Directory:              ".",
+			CodeownersFileLocation: "CODEOWNERS",
+		})
+		got, conflicts, err := codeowners.ApplyFixes([]byte(input), validateFile(t, validator, input))
+		if err != nil || len(conflicts) > 0 {
+			t.Fatalf("Input: %q, Want: no conflict, Got: %v %v", input, conflicts, err)
+		}
+
+		file, err := codeowners.Parse(strings.NewReader(input))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var want bytes.Buffer
+		err = codeowners.NewEncoder(&want).Encode(codeowners.Format(file))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != want.String() {
+			t.Errorf("Input: %q, Want: %q, Got: %q", input, want.String(), string(got))
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package checkers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/fmenezes/codeowners"
+)
+
+// giteaPageSize is the number of items requested per page when listing from Gitea
+const giteaPageSize int = 50
+
+// giteaAPI looks up owners of a Gitea or Forgejo repository with the REST API,
+// users are collaborators of the repository and groups are teams of the organization owning it
+type giteaAPI struct {
+	*restClient
+	repoOwner string
+	repoName  string
+}
+
+type giteaUser struct {
+	Login string `json:"login"`
+	Email string `json:"email"` // Email is hidden unless public or the token belongs to an administrator
+}
+
+type giteaTeam struct {
+	ID         int64             `json:"id"`
+	Name       string            `json:"name"`
+	Permission string            `json:"permission"`
+	UnitsMap   map[string]string `json:"units_map"`
+}
+
+// newGiteaAPI sets up the Gitea client and resolves the repository once, they are shared by every lookup.
+// The CA file, proxy, repository and remote options apply to Gitea the same way they do to Github.
+func newGiteaAPI(options codeowners.ValidatorOptions) (*giteaAPI, error) {
+	if len(options.GiteaURL) == 0 {
+		return nil, errors.New("Missing Gitea URL")
+	}
+	client, err := newRESTClient(options.Context, options.GiteaURL, "token "+options.GiteaToken, options.CAFile, options.Proxy)
+	if err != nil {
+		return nil, err
+	}
+
+	g := &giteaAPI{restClient: client}
+	repository := options.Repository
+	if len(repository) == 0 {
+		repository, err = remoteRepositoryPath(options.Directory, options.Remote, g.host())
+		if err != nil {
+			return nil, err
+		}
+	}
+	if _, err = parseProjectPath(repository); err != nil || strings.Count(repository, "/") != 1 {
+		return nil, fmt.Errorf("Invalid repository '%s', expected owner/name", repository)
+	}
+	parts := strings.Split(repository, "/")
+	g.repoOwner, g.repoName = parts[0], parts[1]
+	return g, nil
+}
+
+// Project returns the repository as owner/name
+func (g *giteaAPI) Project() string {
+	return fmt.Sprintf("%s/%s", g.repoOwner, g.repoName)
+}
+
+// ResolveIdentity returns the user or team of a @user or @org/team owner,
+// emails are searched among the emails the token can see
+func (g *giteaAPI) ResolveIdentity(owner string) (Identity, error) {
+	if strings.HasPrefix(owner, "@") {
+		name := strings.TrimPrefix(owner, "@")
+		if strings.Contains(name, "/") {
+			return Identity{Kind: GroupIdentity, Name: name}, nil
+		}
+		return Identity{Kind: UserIdentity, Name: name}, nil
+	}
+
+	res := struct {
+		Data []giteaUser `json:"data"`
+	}{}
+	_, err := g.get("users/search", url.Values{"q": {owner}}, &res)
+	if err != nil {
+		return Identity{}, err
+	}
+	for _, user := range res.Data {
+		if strings.EqualFold(user.Email, owner) {
+			return Identity{Kind: UserIdentity, Name: user.Login}, nil
+		}
+	}
+	return Identity{Kind: UnknownIdentity, Name: owner}, nil
+}
+
+// Permission returns the access level of a user to the repository, or of a team of the organization owning it
+func (g *giteaAPI) Permission(identity Identity) (string, error) {
+	switch identity.Kind {
+	case UserIdentity:
+		permission := struct {
+			Permission string `json:"permission"`
+		}{}
+		resp, err := g.get(fmt.Sprintf("repos/%s/%s/collaborators/%s/permission", url.PathEscape(g.repoOwner), url.PathEscape(g.repoName), url.PathEscape(identity.Name)), nil, &permission)
+		if err != nil {
+			return "", err
+		}
+		if resp.StatusCode == http.StatusNotFound {
+			return "none", nil // the user does not exist
+		}
+		return giteaAccessLevel(permission.Permission), nil
+	case GroupIdentity:
+		org, name := splitTeam(identity.Name)
+		if !strings.EqualFold(org, g.repoOwner) {
+			return "none", nil // only teams of the organization owning the repository can have access
+		}
+		team := giteaTeam{}
+		resp, err := g.get(fmt.Sprintf("repos/%s/%s/teams/%s", url.PathEscape(g.repoOwner), url.PathEscape(g.repoName), url.PathEscape(name)), nil, &team)
+		if err != nil {
+			return "", err
+		}
+		if resp.StatusCode == http.StatusNotFound {
+			return "none", nil // the team has no access to the repository
+		}
+		if team.Permission == "" || team.Permission == "none" {
+			return giteaAccessLevel(team.UnitsMap["repo.code"]), nil // teams with per unit permissions
+		}
+		return giteaAccessLevel(team.Permission), nil
+	}
+	return "none", nil
+}
+
+// giteaAccessLevel converts a Gitea permission into the access levels shared with Github
+func giteaAccessLevel(permission string) string {
+	switch permission {
+	case "owner", "admin":
+		return "admin"
+	case "write", "read":
+		return permission
+	}
+	return "none"
+}
+
+// ExpandGroup lists the members of a team given as org/team
+func (g *giteaAPI) ExpandGroup(group string) ([]string, error) {
+	org, name := splitTeam(group)
+	res := struct {
+		Data []giteaTeam `json:"data"`
+	}{}
+	_, err := g.get(fmt.Sprintf("orgs/%s/teams/search", url.PathEscape(org)), url.Values{"q": {name}}, &res)
+	if err != nil {
+		return nil, err
+	}
+	var team *giteaTeam
+	for i := range res.Data {
+		if strings.EqualFold(res.Data[i].Name, name) {
+			team = &res.Data[i]
+		}
+	}
+	if team == nil {
+		return nil, fmt.Errorf("Team %s not found", group)
+	}
+
+	logins := []string{}
+	for page := 1; ; page++ {
+		members := []giteaUser{}
+		query := url.Values{"page": {strconv.Itoa(page)}, "limit": {strconv.Itoa(giteaPageSize)}}
+		_, err := g.get(fmt.Sprintf("teams/%d/members", team.ID), query, &members)
+		if err != nil {
+			return nil, err
+		}
+		for _, member := range members {
+			logins = append(logins, member.Login)
+		}
+		if len(members) < giteaPageSize {
+			break
+		}
+	}
+	sort.Strings(logins)
+	return logins, nil
+}
@@ -0,0 +1,73 @@
+// +build unit
+
+package checkers
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+)
+
+var giteaServer *httptest.Server
+var giteaServerOnce sync.Once
+
+// giteaURL returns the API base URL of the mocked Gitea, started on first use
+func giteaURL() string {
+	giteaServerOnce.Do(func() {
+		giteaServer = httptest.NewServer(http.HandlerFunc(mockedGitea))
+	})
+	return giteaServer.URL + "/api/v1"
+}
+
+// mockedGitea answers the Gitea endpoints for the repository org/repo, where writer can write and reader can read,
+// the devs team can write, the readers team can read and the units team can write code through per unit permissions.
+// The devs team has 51 members, spanning two pages.
+func mockedGitea(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "token token" {
+		w.WriteHeader(401)
+		return
+	}
+	body := ""
+	switch r.URL.Path + "?" + r.URL.RawQuery {
+	case "/api/v1/repos/org/repo/collaborators/writer/permission?":
+		body = `{"permission":"write","role_name":"write"}`
+	case "/api/v1/repos/org/repo/collaborators/reader/permission?":
+		body = `{"permission":"read","role_name":"read"}`
+	case "/api/v1/repos/org/repo/collaborators/owner/permission?":
+		body = `{"permission":"owner","role_name":"owner"}`
+	case "/api/v1/repos/org/repo/collaborators/stranger/permission?":
+		body = `{"permission":"none","role_name":"none"}`
+	case "/api/v1/repos/org/repo/collaborators/broken/permission?":
+		w.WriteHeader(500)
+		return
+	case "/api/v1/repos/org/repo/teams/devs?":
+		body = `{"id":1,"name":"devs","permission":"write"}`
+	case "/api/v1/repos/org/repo/teams/readers?":
+		body = `{"id":2,"name":"readers","permission":"read"}`
+	case "/api/v1/repos/org/repo/teams/units?":
+		body = `{"id":3,"name":"units","permission":"none","units_map":{"repo.code":"write","repo.issues":"read"}}`
+	case "/api/v1/users/search?q=writer%40example.com":
+		body = `{"ok":true,"data":[{"login":"writer","email":"writer@example.com"}]}`
+	case "/api/v1/users/search?q=unknown%40example.com":
+		body = `{"ok":true,"data":[]}`
+	case "/api/v1/orgs/org/teams/search?q=devs":
+		body = `{"ok":true,"data":[{"id":1,"name":"devs"},{"id":4,"name":"devs-old"}]}`
+	case "/api/v1/teams/1/members?limit=50&page=1":
+		members := []string{}
+		for i := 1; i <= 50; i++ {
+			members = append(members, fmt.Sprintf(`{"login":"member%02d"}`, i))
+		}
+		body = "[" + strings.Join(members, ",") + "]"
+	case "/api/v1/teams/1/members?limit=50&page=2":
+		body = `[{"login":"member51"}]`
+	default:
+		w.WriteHeader(404)
+		fmt.Fprint(w, `{"message":"The target couldn't be found."}`)
+		return
+	}
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(200)
+	fmt.Fprint(w, body)
+}
@@ -0,0 +1,138 @@
+// +build unit
+
+package checkers
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/fmenezes/codeowners"
+)
+
+func giteaOptions() codeowners.ValidatorOptions {
+	return codeowners.ValidatorOptions{
+		Directory:              ".",
+		CodeownersFileLocation: "CODEOWNERS",
+		Platform:               codeowners.GiteaPlatform,
+		GiteaURL:               giteaURL(),
+		GiteaToken:             "token",
+		Repository:             "org/repo",
+	}
+}
+
+func TestGiteaAccess(t *testing.T) {
+	tests := []struct {
+		owner   string
+		want    bool
+		wantErr bool
+	}{
+		{owner: "@writer", want: true},
+		{owner: "@owner", want: true},
+		{owner: "@reader", want: false},
+		{owner: "@stranger", want: false},
+		{owner: "@ghost", want: false},
+		{owner: "@org/devs", want: true},
+		{owner: "@ORG/units", want: true},
+		{owner: "@org/readers", want: false},
+		{owner: "@org/missing", want: false},
+		{owner: "@other/devs", want: false},
+		{owner: "writer@example.com", want: true},
+		{owner: "unknown@example.com", want: true},
+		{owner: "@broken", wantErr: true},
+	}
+	api, err := newGiteaAPI(giteaOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range tests {
+		got, err := resolverSource{api}.ownerHasWriteAccess(test.owner)
+		if (err != nil) != test.wantErr || got != test.want {
+			t.Errorf("Input: %v, Want: %v %v, Got: %v %v", test.owner, test.want, test.wantErr, got, err)
+		}
+	}
+}
+
+func TestGiteaAccessValidator(t *testing.T) {
+	validator := Access{}.NewValidator(giteaOptions())
+	got := validator.ValidateLine(1, `docs/.* @writer @reader`)
+	want := []codeowners.CheckResult{
+		{
+			Position:  codeowners.Position{FilePath: "CODEOWNERS", StartLine: 1, StartColumn: 17, EndLine: 1, EndColumn: 24},
+			Message:   "Owner '@reader' has no write access to org/repo",
+			Severity:  codeowners.Error,
+			CheckName: "Access",
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Want: %v, Got: %v", want, got)
+	}
+}
+
+func TestGiteaAccessValidatorGiteaOwners(t *testing.T) {
+	validator := Access{}.NewValidator(giteaOptions())
+	var got []string
+	for _, result := range validator.ValidateLine(1, `docs/.* @jane_doe @john.doe`) {
+		got = append(got, result.Message)
+	}
+	want := []string{
+		"Owner '@jane_doe' has no write access to org/repo",
+		"Owner '@john.doe' has no write access to org/repo",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Want: %v, Got: %v", want, got)
+	}
+}
+
+func TestGiteaExpandGroup(t *testing.T) {
+	api, err := newGiteaAPI(giteaOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{}
+	for i := 1; i <= 51; i++ {
+		want = append(want, fmt.Sprintf("member%02d", i))
+	}
+	got, err := api.ExpandGroup("org/devs")
+	if err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("Want: %v, Got: %v %v", want, got, err)
+	}
+	_, err = api.ExpandGroup("org/missing")
+	if err == nil {
+		t.Errorf("Want: error, Got: %v", err)
+	}
+}
+
+func TestGiteaRepository(t *testing.T) {
+	tests := []struct {
+		directory  string
+		giteaURL   string
+		repository string
+		want       string
+		wantErr    string
+	}{
+		{directory: ".", giteaURL: "https://github.com/api/v1", want: "owner/repo"},
+		{directory: "bad", giteaURL: "https://gitea.example.com/api/v1", repository: "my_org/my.repo", want: "my_org/my.repo"},
+		{directory: ".", giteaURL: "https://gitea.example.com/api/v1",
+			wantErr: "Remote git@github.com:owner/repo.git is not hosted on gitea.example.com, set the repository explicitly"},
+		{directory: ".", giteaURL: "https://gitea.example.com/api/v1", repository: "org/sub/repo", wantErr: "Invalid repository 'org/sub/repo', expected owner/name"},
+		{directory: ".", wantErr: "Missing Gitea URL"},
+	}
+	for _, test := range tests {
+		api, err := newGiteaAPI(codeowners.ValidatorOptions{
+			Directory:  test.directory,
+			GiteaURL:   test.giteaURL,
+			GiteaToken: "token",
+			Repository: test.repository,
+		})
+		if len(test.wantErr) > 0 {
+			if err == nil || err.Error() != test.wantErr {
+				t.Errorf("Input: %v, Want: %v, Got: %v", test, test.wantErr, err)
+			}
+			continue
+		}
+		if err != nil || api.Project() != test.want {
+			t.Errorf("Input: %v, Want: %v, Got: %v", test, test.want, err)
+		}
+	}
+}
@@ -3,76 +3,258 @@ package checkers
 import (
 	"context"
 	"fmt"
-	"regexp"
+	"net/http"
+	"net/url"
+	"path/filepath"
 	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/fmenezes/codeowners"
 	"github.com/google/go-github/v32/github"
+	"golang.org/x/oauth2"
 )
 
 type accessAPI struct {
-	directory   string
-	tokenType   string
-	token       string
-	repoURL     string
-	repoOwner   string
-	repoName    string
-	accessLevel string
+	directory string
+	tokenType string
+	token     string
+	baseURL   string
+	uploadURL string
+	caFile    string
+	proxy     string
 
-	client *github.Client
-	ctx    context.Context
+	appID             int64
+	appKeyFile        string
+	appInstallationID int64
+
+	remote       string
+	explicitRepo string // explicitRepo is the owner/name given explicitly, the remote is not read when set
+	repoHost     string
+	repoOwner    string
+	repoName     string
+
+	client    *github.Client
+	ctx       context.Context
+	etagStore ETagStore // etagStore keeps the responses revalidated with conditional requests
+
+	retryBudget int32 // retryBudget is how many rate limited requests may still be retried
+	sleep       func(ctx context.Context, d time.Duration) error
+}
+
+// newAccessAPI sets up the Github client and resolves the repository once, they are shared by every lookup
+func newAccessAPI(options codeowners.ValidatorOptions) (*accessAPI, error) {
+	ctx := options.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	a := &accessAPI{
+		ctx:       ctx,
+		directory: options.Directory,
+		token:     options.GithubToken,
+		tokenType: options.GithubTokenType,
+		baseURL:   options.GithubURL,
+		uploadURL: options.GithubUploadURL,
+		caFile:    options.CAFile,
+		proxy:     options.Proxy,
+
+		remote:       options.Remote,
+		explicitRepo: options.Repository,
+
+		appID:             options.GithubAppID,
+		appKeyFile:        options.GithubAppPrivateKeyFile,
+		appInstallationID: options.GithubAppInstallationID,
+
+		retryBudget: defaultRetryBudget,
+		sleep:       sleepContext,
+		etagStore:   defaultETagStore,
+	}
+	if dir := options.Options[AccessCacheDirOption]; len(dir) > 0 {
+		a.etagStore = NewFileETagStore(filepath.Join(dir, etagCacheDir))
+	}
+	if len(a.uploadURL) == 0 {
+		a.uploadURL = a.baseURL
+	}
+
+	if len(a.remote) == 0 {
+		a.remote = defaultRemote
+	}
+
+	err := a.resolveRepository()
+	if err != nil {
+		return nil, err
+	}
+
+	err = a.initiateClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return a, nil
 }
 
-func (a *accessAPI) extractRepoData() {
-	r := regexp.MustCompile(`github\.com[\:\/]([A-Za-z0-9-]+)\/([A-Za-z0-9-]+)(?:\.git)?$`)
-	data := r.FindStringSubmatch(a.repoURL)
-	a.repoOwner = data[1]
-	a.repoName = data[2]
+// hasGithubCredentials tells whether a token or a Github App is configured to call Github with
+func hasGithubCredentials(options codeowners.ValidatorOptions) bool {
+	return len(options.GithubToken) > 0 || options.GithubAppID != 0
 }
 
-func (a accessAPI) fetchTeamAccess(org, team string) (string, error) {
-	repo, _, err := a.client.Teams.IsTeamRepoBySlug(a.ctx, org, team, a.repoOwner, a.repoName)
+// newGithubClient returns a client of github.com, or of the Github Enterprise Server at baseURL when set
+func newGithubClient(httpClient *http.Client, baseURL, uploadURL string) (*github.Client, error) {
+	if len(baseURL) == 0 {
+		return github.NewClient(httpClient), nil
+	}
+	return github.NewEnterpriseClient(baseURL, uploadURL, httpClient)
+}
+
+// setupClient sets up the client calling Github at baseURL, authenticated either as the Github App installation or with the token
+func (a *accessAPI) setupClient(baseURL, uploadURL string) error {
+	transport, err := newTransport(a.caFile, a.proxy)
+	if err != nil {
+		return err
+	}
+
+	var tokenSource oauth2.TokenSource
+	if a.appID != 0 {
+		tokenSource, err = a.appTokenSource(transport, baseURL, uploadURL)
+		if err != nil {
+			return err
+		}
+	} else {
+		tokenSource = oauth2.StaticTokenSource(
+			&oauth2.Token{AccessToken: a.token, TokenType: a.tokenType},
+		)
+	}
+
+	// the conditional requests transport sits below oauth2 so it sees the credentials of each request
+	ctx := context.WithValue(a.ctx, oauth2.HTTPClient, &http.Client{
+		Transport: newETagTransport(transport, a.etagStore),
+	})
+	a.client, err = newGithubClient(oauth2.NewClient(ctx, tokenSource), baseURL, uploadURL)
+	return err
+}
+
+// host returns the host name of the Github instance, github.com unless an enterprise base URL is set
+func (a *accessAPI) host() (string, error) {
+	if len(a.baseURL) == 0 {
+		return "github.com", nil
+	}
+	u, err := url.Parse(a.baseURL)
 	if err != nil {
 		return "", err
 	}
-	data := repo.GetPermissions()
-	if data["admin"] {
-		return "admin", nil
+	if len(u.Hostname()) == 0 {
+		return "", fmt.Errorf("Invalid Github URL %s", a.baseURL)
 	}
-	if data["maintain"] {
-		return "maintain", nil
+	return u.Hostname(), nil
+}
+
+// Project returns the resolved repository as owner/name
+func (a *accessAPI) Project() string {
+	return fmt.Sprintf("%s/%s", a.repoOwner, a.repoName)
+}
+
+// resolveRepository sets the repository checked, the one given explicitly or else the one of the git remote
+func (a *accessAPI) resolveRepository() error {
+	host, err := a.host()
+	if err != nil {
+		return err
 	}
-	if data["push"] {
-		return "push", nil
+
+	if len(a.explicitRepo) > 0 {
+		a.repoHost = host
+		a.repoOwner, a.repoName, err = parseRepository(a.explicitRepo)
+		return err
 	}
-	return "none", nil
+
+	path, err := remoteRepositoryPath(a.directory, a.remote, host)
+	if err != nil {
+		return err
+	}
+	a.repoOwner, a.repoName, err = parseRepository(path)
+	if err != nil {
+		return fmt.Errorf("Could not find the repository in remote '%s', set the repository explicitly", a.remote)
+	}
+	a.repoHost = host
+	return nil
+}
+
+// withRetry calls fn again while Github reports a rate limit, waiting as long as the response tells.
+// Retries are taken from the retry budget, waits longer than maxRateLimitWait give up straight away.
+func (a *accessAPI) withRetry(fn func() (*github.Response, error)) (*github.Response, error) {
+	for {
+		resp, err := fn()
+		wait, limited := rateLimitWait(resp, err, time.Now())
+		if !limited || wait > maxRateLimitWait || atomic.AddInt32(&a.retryBudget, -1) < 0 {
+			return resp, err
+		}
+		if sleepErr := a.sleep(a.ctx, wait); sleepErr != nil {
+			return resp, sleepErr
+		}
+	}
+}
+
+func (a *accessAPI) fetchTeamAccess(org, team string) (string, error) {
+	var repo *github.Repository
+	resp, err := a.withRetry(func() (resp *github.Response, err error) {
+		repo, resp, err = a.client.Teams.IsTeamRepoBySlug(a.ctx, org, team, a.repoOwner, a.repoName)
+		return resp, err
+	})
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return "none", nil // the team has no access to the repository
+	}
+	if err != nil {
+		return "", err
+	}
+	return permissionLevel(repo.GetPermissions()), nil
+}
+
+// permissionLevel returns the highest access level among the permissions Github lists for a user or team
+func permissionLevel(permissions map[string]bool) string {
+	for _, level := range []string{"admin", "maintain", "push", "triage", "pull"} {
+		if permissions[level] {
+			return level
+		}
+	}
+	return "none"
 }
 
-func (a accessAPI) hasWriteAccess() bool {
-	switch a.accessLevel {
-	case "admin", "push", "maintain", "write", "email": // allowing emails to pass
+func hasWriteAccess(accessLevel string) bool {
+	switch accessLevel {
+	case "admin", "push", "maintain", "write":
 		return true
 	}
 	return false
 }
 
-func (a accessAPI) fetchUserAccess(user string) (string, error) {
-	isCollaborator, _, err := a.client.Repositories.IsCollaborator(a.ctx, a.repoOwner, a.repoName, user)
+func (a *accessAPI) fetchUserAccess(user string) (string, error) {
+	var isCollaborator bool
+	_, err := a.withRetry(func() (resp *github.Response, err error) {
+		isCollaborator, resp, err = a.client.Repositories.IsCollaborator(a.ctx, a.repoOwner, a.repoName, user)
+		return resp, err
+	})
 	if err != nil {
 		return "", err
 	}
 	if !isCollaborator {
 		return "none", nil
 	}
-	permissionLevel, _, err := a.client.Repositories.GetPermissionLevel(a.ctx, a.repoOwner, a.repoName, user)
+	var permissionLevel *github.RepositoryPermissionLevel
+	_, err = a.withRetry(func() (resp *github.Response, err error) {
+		permissionLevel, resp, err = a.client.Repositories.GetPermissionLevel(a.ctx, a.repoOwner, a.repoName, user)
+		return resp, err
+	})
 	if err != nil {
 		return "", err
 	}
 	return permissionLevel.GetPermission(), nil
 }
 
-func (a accessAPI) findUserFromEmail(email string) (string, error) {
-	res, _, err := a.client.Search.Users(a.ctx, fmt.Sprintf("%s in:email type:user", email), nil)
+func (a *accessAPI) findUserFromEmail(email string) (string, error) {
+	var res *github.UsersSearchResult
+	_, err := a.withRetry(func() (resp *github.Response, err error) {
+		res, resp, err = a.client.Search.Users(a.ctx, fmt.Sprintf("%s in:email type:user", email), nil)
+		return resp, err
+	})
 	if err != nil {
 		return "", err
 	}
@@ -82,60 +264,49 @@ func (a accessAPI) findUserFromEmail(email string) (string, error) {
 	return "", nil
 }
 
-func (a *accessAPI) fetchAccess(user string) error {
-	var err error
-	login := ""
-	if string(user[0]) == "@" {
-		login = strings.TrimPrefix(user, "@")
-	} else {
-		login, err = a.findUserFromEmail(user)
-		if err != nil {
-			return err
-		}
-	}
-
-	if len(login) == 0 {
-		a.accessLevel = "email"
-		return nil
-	}
-
-	if strings.Index(login, "/") >= 0 {
-		parts := strings.Split(login, "/")
-		access, err := a.fetchTeamAccess(parts[0], parts[1])
-		if err != nil {
-			return err
+// ResolveIdentity returns the user or team of a @user or @org/team owner, emails are searched among public emails
+func (a *accessAPI) ResolveIdentity(owner string) (Identity, error) {
+	if strings.HasPrefix(owner, "@") {
+		name := strings.TrimPrefix(owner, "@")
+		if strings.Contains(name, "/") {
+			return Identity{Kind: GroupIdentity, Name: name}, nil
 		}
-		a.accessLevel = access
-		return nil
+		return Identity{Kind: UserIdentity, Name: name}, nil
 	}
 
-	access, err := a.fetchUserAccess(login)
+	login, err := a.findUserFromEmail(owner)
 	if err != nil {
-		return err
+		return Identity{}, err
 	}
-	a.accessLevel = access
-	return nil
+	if len(login) == 0 {
+		return Identity{Kind: UnknownIdentity, Name: owner}, nil
+	}
+	return Identity{Kind: UserIdentity, Name: login}, nil
 }
 
-func ownerHasWriteAccess(options codeowners.ValidatorOptions, user string) (bool, error) {
-	a := accessAPI{
-		ctx:       context.Background(),
-		directory: options.Directory,
-		token:     options.GithubToken,
-		tokenType: options.GithubTokenType,
+// Permission returns the access level of a collaborator or of a team to the repository
+func (a *accessAPI) Permission(identity Identity) (string, error) {
+	switch identity.Kind {
+	case UserIdentity:
+		return a.fetchUserAccess(identity.Name)
+	case GroupIdentity:
+		org, team := splitTeam(identity.Name)
+		return a.fetchTeamAccess(org, team)
 	}
-	a.initiateClient()
-	defer a.terminateClient()
+	return "none", nil
+}
 
-	err := a.extractRepoURL()
-	if err != nil {
-		return false, err
-	}
-	a.extractRepoData()
+// ExpandGroup lists the members of a team given as org/team
+func (a *accessAPI) ExpandGroup(group string) ([]string, error) {
+	org, team := splitTeam(group)
+	return a.teamMembers(org, team)
+}
 
-	err = a.fetchAccess(user)
-	if err != nil {
-		return false, err
+// splitTeam splits a team given as org/team
+func splitTeam(team string) (string, string) {
+	parts := strings.SplitN(team, "/", 2)
+	if len(parts) < 2 {
+		return parts[0], ""
 	}
-	return a.hasWriteAccess(), nil
+	return parts[0], parts[1]
 }
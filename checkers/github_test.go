@@ -0,0 +1,302 @@
+// +build unit
+
+package checkers
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/fmenezes/codeowners"
+)
+
+func TestAccessResolvesRepositoryOnce(t *testing.T) {
+	repoURLLookups = 0
+	validator := Access{}.NewValidator(codeowners.ValidatorOptions{
+		Directory:              ".",
+		CodeownersFileLocation: "CODEOWNERS",
+		GithubToken:            "token",
+	}).(*accessValidator)
+
+	validator.ValidateLine(1, "filepattern @ownerWithAccess @owner")
+	validator.ValidateLine(2, "filepattern @github/justice-league")
+
+	if repoURLLookups != 1 {
+		t.Errorf("Want: 1 lookup, Got: %d", repoURLLookups)
+	}
+	if validator.source.repository() != "owner/repo" {
+		t.Errorf("Want: owner/repo, Got: %s", validator.source.repository())
+	}
+}
+
+func TestAccessPrefetchesDistinctOwners(t *testing.T) {
+	file, err := codeowners.Parse(strings.NewReader("file1 @owner\nfile2 @ownerWithAccess @owner\nfile3 invalid @ownerWithAccess\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	validator := Access{}.NewValidator(codeowners.ValidatorOptions{
+		Directory:              ".",
+		CodeownersFileLocation: "CODEOWNERS",
+		GithubToken:            "token",
+		Options:                map[string]string{AccessConcurrencyOption: "2"},
+	}).(*accessValidator)
+
+	atomic.StoreInt32(&collaboratorLookups, 0)
+	validator.Begin(file)
+	if got := atomic.LoadInt32(&collaboratorLookups); got != 2 {
+		t.Errorf("Want: 2 lookups, Got: %d", got)
+	}
+
+	lines := []int{}
+	for _, node := range file.Rules() {
+		for _, result := range validator.ValidateLine(node.Span.Line, node.Raw) {
+			lines = append(lines, result.Position.StartLine)
+		}
+	}
+	if got := atomic.LoadInt32(&collaboratorLookups); got != 2 {
+		t.Errorf("Want: 2 lookups after validating, Got: %d", got)
+	}
+	if len(lines) != 2 || lines[0] != 1 || lines[1] != 2 {
+		t.Errorf("Want: results on lines [1 2], Got: %v", lines)
+	}
+}
+
+func TestAccessPrefetchStopsWhenCancelled(t *testing.T) {
+	file, err := codeowners.Parse(strings.NewReader("file1 @owner\nfile2 @ownerWithAccess\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	validator := Access{}.NewValidator(codeowners.ValidatorOptions{
+		Context:                ctx,
+		Directory:              ".",
+		CodeownersFileLocation: "CODEOWNERS",
+		GithubToken:            "token",
+	}).(*accessValidator)
+	cancel()
+
+	validator.Begin(file)
+	if got := len(validator.accessMemo); got != 0 {
+		t.Errorf("Want: 0 lookups, Got: %d", got)
+	}
+}
+
+func TestAccessConcurrency(t *testing.T) {
+	tests := []struct {
+		input string
+		want  int
+	}{
+		{input: "", want: DefaultAccessConcurrency},
+		{input: "3", want: 3},
+		{input: "0", want: DefaultAccessConcurrency},
+		{input: "many", want: DefaultAccessConcurrency},
+	}
+	for _, test := range tests {
+		validator := &accessValidator{options: codeowners.ValidatorOptions{
+			Options: map[string]string{AccessConcurrencyOption: test.input},
+		}}
+		got := validator.concurrency()
+		if got != test.want {
+			t.Errorf("Input: %v, Want: %v, Got: %v", test.input, test.want, got)
+		}
+	}
+}
+
+func TestAccessRetriesRateLimits(t *testing.T) {
+	alwaysThrottledWaits := make([]time.Duration, defaultRetryBudget)
+	for i := range alwaysThrottledWaits {
+		alwaysThrottledWaits[i] = time.Second
+	}
+	tests := []struct {
+		owner     string
+		wantWaits []time.Duration
+		wantCheck string
+	}{
+		{owner: "@primaryLimited", wantWaits: []time.Duration{0}, wantCheck: "Access"},
+		{owner: "@secondaryLimited", wantWaits: []time.Duration{7 * time.Second}, wantCheck: "Access"},
+		{owner: "@throttledLimited", wantWaits: []time.Duration{3 * time.Second}, wantCheck: "Access"},
+		{owner: "@alwaysThrottled", wantWaits: alwaysThrottledWaits, wantCheck: "AccessCheckFailed"},
+		{owner: "@throttledForAnHour", wantWaits: []time.Duration{}, wantCheck: "AccessCheckFailed"},
+	}
+	for _, test := range tests {
+		resetMockAttempts()
+		validator := Access{}.NewValidator(codeowners.ValidatorOptions{
+			Directory:              ".",
+			CodeownersFileLocation: "CODEOWNERS",
+			GithubToken:            "token",
+		}).(*accessValidator)
+		waits := []time.Duration{}
+		validator.source.(resolverSource).resolver.(*accessAPI).sleep = func(ctx context.Context, d time.Duration) error {
+			waits = append(waits, d)
+			return nil
+		}
+
+		got := validator.ValidateLine(1, "filepattern "+test.owner)
+		if len(got) != 1 || got[0].CheckName != test.wantCheck {
+			t.Errorf("Input: %v, Want: %v, Got: %v", test.owner, test.wantCheck, got)
+		}
+		if !reflect.DeepEqual(waits, test.wantWaits) {
+			t.Errorf("Input: %v, Want: %v, Got: %v", test.owner, test.wantWaits, waits)
+		}
+	}
+}
+
+func TestAccessRetryStopsWhenContextIsDone(t *testing.T) {
+	resetMockAttempts()
+	ctx, cancel := context.WithCancel(context.Background())
+	validator := Access{}.NewValidator(codeowners.ValidatorOptions{
+		Context:                ctx,
+		Directory:              ".",
+		CodeownersFileLocation: "CODEOWNERS",
+		GithubToken:            "token",
+	}).(*accessValidator)
+	validator.source.(resolverSource).resolver.(*accessAPI).sleep = func(ctx context.Context, d time.Duration) error {
+		cancel()
+		return sleepContext(ctx, d)
+	}
+
+	got := validator.ValidateLine(1, "filepattern @secondaryLimited")
+	if len(got) != 1 || got[0].Cause != context.Canceled {
+		t.Errorf("Want: %v, Got: %v", context.Canceled, got)
+	}
+}
+
+func TestAccessConditionalRequests(t *testing.T) {
+	defaultETagStore = NewMemoryETagStore()
+	atomic.StoreInt32(&notModifiedResponses, 0)
+
+	for i := 0; i < 2; i++ {
+		validator := Access{}.NewValidator(codeowners.ValidatorOptions{
+			Directory:              ".",
+			CodeownersFileLocation: "CODEOWNERS",
+			GithubToken:            "token",
+		})
+		got := validator.ValidateLine(1, "filepattern @ownerWithAccess")
+		if got != nil {
+			t.Errorf("Run: %d, Want: %v, Got: %v", i, nil, got)
+		}
+	}
+
+	if got := atomic.LoadInt32(&notModifiedResponses); got != 1 {
+		t.Errorf("Want: 1 not modified response, Got: %d", got)
+	}
+}
+
+func TestAccessEnterpriseRemote(t *testing.T) {
+	tests := []struct {
+		directory string
+		githubURL string
+		wantErr   bool
+	}{
+		{directory: "enterprise", githubURL: "https://ghe.example.com/api/v3/", wantErr: false},
+		{directory: "enterprise", githubURL: "https://GHE.example.com", wantErr: false},
+		{directory: "enterprise", githubURL: "", wantErr: true},
+		{directory: ".", githubURL: "https://ghe.example.com", wantErr: true},
+		{directory: ".", githubURL: "/api/v3", wantErr: true},
+	}
+	for _, test := range tests {
+		api, err := newAccessAPI(codeowners.ValidatorOptions{
+			Directory:   test.directory,
+			GithubToken: "token",
+			GithubURL:   test.githubURL,
+		})
+		if (err != nil) != test.wantErr {
+			t.Errorf("Input: %v, Want error: %v, Got: %v", test, test.wantErr, err)
+			continue
+		}
+		if err == nil && api.Project() != "owner/repo" {
+			t.Errorf("Input: %v, Want: owner/repo, Got: %s", test, api.Project())
+		}
+	}
+}
+
+func TestAccessRepository(t *testing.T) {
+	tests := []struct {
+		directory  string
+		remote     string
+		repository string
+		want       string
+		wantErr    string
+	}{
+		{directory: ".", want: "owner/repo"},
+		{directory: ".", remote: "upstream", want: "my-org/my.repo_name"},
+		{directory: "bad", repository: "other/other.repo", want: "other/other.repo"},
+		{directory: ".", remote: "missing", wantErr: "Could not read the URL of remote 'missing', set the repository explicitly: exit status 1"},
+		{directory: ".", repository: "other", wantErr: "Invalid repository 'other', expected owner/name"},
+	}
+	for _, test := range tests {
+		api, err := newAccessAPI(codeowners.ValidatorOptions{
+			Directory:   test.directory,
+			GithubToken: "token",
+			Remote:      test.remote,
+			Repository:  test.repository,
+		})
+		if len(test.wantErr) > 0 {
+			if err == nil || err.Error() != test.wantErr {
+				t.Errorf("Input: %v, Want: %v, Got: %v", test, test.wantErr, err)
+			}
+			continue
+		}
+		if err != nil || api.Project() != test.want {
+			t.Errorf("Input: %v, Want: %v, Got: %v", test, test.want, err)
+		}
+	}
+}
+
+func TestAccessUsesCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "codeowners")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	wantLookups := []int32{1, 0}
+	for run, want := range wantLookups {
+		atomic.StoreInt32(&collaboratorLookups, 0)
+		validator := Access{}.NewValidator(codeowners.ValidatorOptions{
+			Directory:              ".",
+			CodeownersFileLocation: "CODEOWNERS",
+			GithubToken:            "token",
+			Options:                map[string]string{AccessCacheDirOption: dir, AccessCacheTTLOption: "10m"},
+		})
+		got := validator.ValidateLine(1, "filepattern @owner")
+		if len(got) != 1 || got[0].CheckName != "Access" {
+			t.Errorf("Run: %d, Want: %v, Got: %v", run, "Access", got)
+		}
+		if lookups := atomic.LoadInt32(&collaboratorLookups); lookups != want {
+			t.Errorf("Run: %d, Want: %d lookups, Got: %d", run, want, lookups)
+		}
+	}
+}
+
+func TestAccessConditionalRequestsAcrossRuns(t *testing.T) {
+	dir, err := ioutil.TempDir("", "codeowners")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	atomic.StoreInt32(&notModifiedResponses, 0)
+
+	for run := 0; run < 2; run++ {
+		defaultETagStore = NewMemoryETagStore() // every run starts as a new process
+		validator := Access{}.NewValidator(codeowners.ValidatorOptions{
+			Directory:              ".",
+			CodeownersFileLocation: "CODEOWNERS",
+			GithubToken:            "token",
+			Options:                map[string]string{AccessCacheDirOption: dir, AccessCacheTTLOption: "1ns"},
+		})
+		got := validator.ValidateLine(1, "filepattern @ownerWithAccess")
+		if got != nil {
+			t.Errorf("Run: %d, Want: %v, Got: %v", run, nil, got)
+		}
+	}
+
+	if got := atomic.LoadInt32(&notModifiedResponses); got != 1 {
+		t.Errorf("Want: 1 not modified response, Got: %d", got)
+	}
+}
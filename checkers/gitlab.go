@@ -0,0 +1,210 @@
+package checkers
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/fmenezes/codeowners"
+)
+
+// defaultGitlabURL is the API base URL of gitlab.com
+const defaultGitlabURL string = "https://gitlab.com/api/v4/"
+
+// gitlabAPI looks up owners of a GitLab project with the REST API, direct and inherited project members are users
+// and groups are either the groups the project belongs to or the groups the project is shared with
+type gitlabAPI struct {
+	*restClient
+	project string // project is the full path of the project, such as group/subgroup/name
+
+	projectOnce sync.Once
+	projectInfo gitlabProject
+	projectErr  error
+}
+
+type gitlabProject struct {
+	Namespace struct {
+		FullPath string `json:"full_path"`
+	} `json:"namespace"`
+	SharedWithGroups []struct {
+		GroupFullPath    string `json:"group_full_path"`
+		GroupAccessLevel int    `json:"group_access_level"`
+	} `json:"shared_with_groups"`
+}
+
+type gitlabUser struct {
+	ID          int64  `json:"id"`
+	Username    string `json:"username"`
+	PublicEmail string `json:"public_email"`
+	Email       string `json:"email"` // Email is only returned to administrators
+	AccessLevel int    `json:"access_level"`
+}
+
+// newGitlabAPI sets up the GitLab client and resolves the project once, they are shared by every lookup.
+// The CA file, proxy, repository and remote options apply to GitLab the same way they do to Github.
+func newGitlabAPI(options codeowners.ValidatorOptions) (*gitlabAPI, error) {
+	baseURL := options.GitlabURL
+	if len(baseURL) == 0 {
+		baseURL = defaultGitlabURL
+	}
+	client, err := newRESTClient(options.Context, baseURL, "Bearer "+options.GitlabToken, options.CAFile, options.Proxy)
+	if err != nil {
+		return nil, err
+	}
+
+	g := &gitlabAPI{restClient: client}
+	if len(options.Repository) > 0 {
+		g.project, err = parseProjectPath(options.Repository)
+		if err != nil {
+			return nil, err
+		}
+		return g, nil
+	}
+	path, err := remoteRepositoryPath(options.Directory, options.Remote, g.host())
+	if err != nil {
+		return nil, err
+	}
+	g.project, err = parseProjectPath(path)
+	if err != nil {
+		return nil, fmt.Errorf("Could not find the project in remote '%s', set the repository explicitly", path)
+	}
+	return g, nil
+}
+
+// Project returns the full path of the project
+func (g *gitlabAPI) Project() string {
+	return g.project
+}
+
+// fetchProject returns the namespace and the group shares of the project, they are fetched once
+func (g *gitlabAPI) fetchProject() (gitlabProject, error) {
+	g.projectOnce.Do(func() {
+		var resp *http.Response
+		resp, g.projectErr = g.get("projects/"+url.PathEscape(g.project), nil, &g.projectInfo)
+		if g.projectErr == nil && resp.StatusCode == http.StatusNotFound {
+			g.projectErr = fmt.Errorf("Project %s not found", g.project)
+		}
+	})
+	return g.projectInfo, g.projectErr
+}
+
+// ResolveIdentity returns the user or group of a @user, @group or @group/subgroup owner,
+// emails are searched among public emails, or every email when the token belongs to an administrator
+func (g *gitlabAPI) ResolveIdentity(owner string) (Identity, error) {
+	if !strings.HasPrefix(owner, "@") {
+		users := []gitlabUser{}
+		_, err := g.get("users", url.Values{"search": {owner}}, &users)
+		if err != nil {
+			return Identity{}, err
+		}
+		for _, user := range users {
+			if strings.EqualFold(user.PublicEmail, owner) || strings.EqualFold(user.Email, owner) {
+				return Identity{Kind: UserIdentity, Name: user.Username, ID: user.ID}, nil
+			}
+		}
+		return Identity{Kind: UnknownIdentity, Name: owner}, nil
+	}
+
+	name := strings.TrimPrefix(owner, "@")
+	if strings.Contains(name, "/") {
+		return Identity{Kind: GroupIdentity, Name: name}, nil // usernames never contain slashes
+	}
+
+	users := []gitlabUser{}
+	_, err := g.get("users", url.Values{"username": {name}}, &users)
+	if err != nil {
+		return Identity{}, err
+	}
+	if len(users) > 0 {
+		return Identity{Kind: UserIdentity, Name: users[0].Username, ID: users[0].ID}, nil
+	}
+
+	resp, err := g.get("groups/"+url.PathEscape(name), nil, &struct{}{})
+	if err != nil {
+		return Identity{}, err
+	}
+	if resp.StatusCode != http.StatusNotFound {
+		return Identity{Kind: GroupIdentity, Name: name}, nil
+	}
+	return Identity{Kind: UserIdentity, Name: name}, nil // an unknown user, it has no access
+}
+
+// Permission returns the access level of a project member, including inherited members,
+// or of a group the project belongs to or is shared with
+func (g *gitlabAPI) Permission(identity Identity) (string, error) {
+	switch identity.Kind {
+	case UserIdentity:
+		if identity.ID == 0 {
+			return "none", nil
+		}
+		member := gitlabUser{}
+		resp, err := g.get(fmt.Sprintf("projects/%s/members/all/%d", url.PathEscape(g.project), identity.ID), nil, &member)
+		if err != nil {
+			return "", err
+		}
+		if resp.StatusCode == http.StatusNotFound {
+			return "none", nil // the user is not a member of the project
+		}
+		return gitlabAccessLevel(member.AccessLevel), nil
+	case GroupIdentity:
+		project, err := g.fetchProject()
+		if err != nil {
+			return "", err
+		}
+		namespace := strings.ToLower(project.Namespace.FullPath)
+		group := strings.ToLower(identity.Name)
+		if namespace == group || strings.HasPrefix(namespace, group+"/") {
+			return "write", nil // the project belongs to the group, its members are eligible code owners
+		}
+		for _, share := range project.SharedWithGroups {
+			if strings.EqualFold(share.GroupFullPath, identity.Name) {
+				return gitlabAccessLevel(share.GroupAccessLevel), nil
+			}
+		}
+	}
+	return "none", nil
+}
+
+// gitlabAccessLevel converts a GitLab access level into the access levels shared with Github
+func gitlabAccessLevel(level int) string {
+	switch {
+	case level >= 50: // Owner
+		return "admin"
+	case level >= 40: // Maintainer
+		return "maintain"
+	case level >= 30: // Developer
+		return "write"
+	case level >= 10: // Guest, Planner and Reporter
+		return "read"
+	}
+	return "none"
+}
+
+// ExpandGroup lists the members of a group given by its full path, including inherited members
+func (g *gitlabAPI) ExpandGroup(group string) ([]string, error) {
+	logins := []string{}
+	page := "1"
+	for len(page) > 0 {
+		members := []gitlabUser{}
+		resp, err := g.get("groups/"+url.PathEscape(group)+"/members/all", url.Values{"per_page": {"100"}, "page": {page}}, &members)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode == http.StatusNotFound {
+			return nil, fmt.Errorf("Group %s not found", group)
+		}
+		for _, member := range members {
+			logins = append(logins, member.Username)
+		}
+		page = resp.Header.Get("X-Next-Page")
+		if _, err := strconv.Atoi(page); err != nil {
+			page = ""
+		}
+	}
+	sort.Strings(logins)
+	return logins, nil
+}
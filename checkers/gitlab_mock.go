@@ -0,0 +1,78 @@
+// +build unit
+
+package checkers
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+)
+
+var gitlabServer *httptest.Server
+var gitlabServerOnce sync.Once
+
+// gitlabURL returns the API base URL of the mocked GitLab, started on first use
+func gitlabURL() string {
+	gitlabServerOnce.Do(func() {
+		gitlabServer = httptest.NewServer(http.HandlerFunc(mockedGitlab))
+	})
+	return gitlabServer.URL + "/api/v4"
+}
+
+// mockedGitlab answers the GitLab endpoints for the project group/project, which belongs to the group named group
+// and is shared with partners/devs as developers and with partners/guests as guests.
+// Projects and groups are matched on their escaped paths, as GitLab expects them URL encoded.
+func mockedGitlab(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "Bearer token" {
+		w.WriteHeader(401)
+		return
+	}
+	body := ""
+	switch r.URL.EscapedPath() + "?" + r.URL.RawQuery {
+	case "/api/v4/projects/group%2Fproject?":
+		body = `{"id":7,"path_with_namespace":"group/project","namespace":{"full_path":"group"},"shared_with_groups":[` +
+			`{"group_id":8,"group_full_path":"partners/devs","group_access_level":30},` +
+			`{"group_id":9,"group_full_path":"partners/guests","group_access_level":10}]}`
+	case "/api/v4/users?username=developer":
+		body = `[{"id":1,"username":"developer"}]`
+	case "/api/v4/users?username=reporter":
+		body = `[{"id":2,"username":"reporter"}]`
+	case "/api/v4/users?username=maintainer":
+		body = `[{"id":3,"username":"maintainer"}]`
+	case "/api/v4/users?username=broken":
+		body = `[{"id":99,"username":"broken"}]`
+	case "/api/v4/users?search=dev%40example.com":
+		body = `[{"id":1,"username":"developer","public_email":"dev@example.com"}]`
+	case "/api/v4/projects/group%2Fproject/members/all/1?":
+		body = `{"id":1,"username":"developer","access_level":30}`
+	case "/api/v4/projects/group%2Fproject/members/all/2?":
+		body = `{"id":2,"username":"reporter","access_level":20}`
+	case "/api/v4/projects/group%2Fproject/members/all/3?":
+		body = `{"id":3,"username":"maintainer","access_level":40}`
+	case "/api/v4/projects/group%2Fproject/members/all/99?":
+		w.WriteHeader(500)
+		return
+	case "/api/v4/groups/group?":
+		body = `{"id":6,"full_path":"group"}`
+	case "/api/v4/groups/group/members/all?page=1&per_page=100":
+		w.Header().Add("X-Next-Page", "2")
+		body = `[{"id":3,"username":"maintainer","access_level":50}]`
+	case "/api/v4/groups/group/members/all?page=2&per_page=100":
+		w.Header().Add("X-Next-Page", "")
+		body = `[{"id":1,"username":"developer","access_level":30}]`
+	case "/api/v4/groups/partners%2Fdevs/members/all?page=1&per_page=100":
+		body = `[{"id":1,"username":"developer","access_level":30}]`
+	default:
+		if r.URL.Path == "/api/v4/users" {
+			body = "[]"
+			break
+		}
+		w.WriteHeader(404)
+		fmt.Fprint(w, `{"message":"404 Not Found"}`)
+		return
+	}
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(200)
+	fmt.Fprint(w, body)
+}
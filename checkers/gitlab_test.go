@@ -0,0 +1,158 @@
+// +build unit
+
+package checkers
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/fmenezes/codeowners"
+)
+
+func gitlabOptions() codeowners.ValidatorOptions {
+	return codeowners.ValidatorOptions{
+		Directory:              ".",
+		CodeownersFileLocation: "CODEOWNERS",
+		Platform:               codeowners.GitlabPlatform,
+		GitlabURL:              gitlabURL(),
+		GitlabToken:            "token",
+		Repository:             "group/project",
+	}
+}
+
+func TestGitlabAccess(t *testing.T) {
+	tests := []struct {
+		owner   string
+		want    bool
+		wantErr bool
+	}{
+		{owner: "@developer", want: true},
+		{owner: "@maintainer", want: true},
+		{owner: "@reporter", want: false},
+		{owner: "@nobody", want: false},
+		{owner: "@group", want: true},
+		{owner: "@Partners/Devs", want: true},
+		{owner: "@partners/guests", want: false},
+		{owner: "@other/group", want: false},
+		{owner: "dev@example.com", want: true},
+		{owner: "unknown@example.com", want: true},
+		{owner: "@broken", wantErr: true},
+	}
+	api, err := newGitlabAPI(gitlabOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range tests {
+		got, err := resolverSource{api}.ownerHasWriteAccess(test.owner)
+		if (err != nil) != test.wantErr || got != test.want {
+			t.Errorf("Input: %v, Want: %v %v, Got: %v %v", test.owner, test.want, test.wantErr, got, err)
+		}
+	}
+}
+
+func TestGitlabAccessValidator(t *testing.T) {
+	validator := Access{}.NewValidator(gitlabOptions())
+	got := validator.ValidateLine(1, "filepattern @developer @reporter")
+	want := []codeowners.CheckResult{
+		{
+			Position:  codeowners.Position{FilePath: "CODEOWNERS", StartLine: 1, StartColumn: 24, EndLine: 1, EndColumn: 33},
+			Message:   "Owner '@reporter' has no write access to group/project",
+			Severity:  codeowners.Error,
+			CheckName: "Access",
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Want: %v, Got: %v", want, got)
+	}
+}
+
+func TestGitlabAccessValidatorGitlabOwners(t *testing.T) {
+	validator := Access{}.NewValidator(gitlabOptions())
+	var got []string
+	for _, result := range validator.ValidateLine(1, "filepattern @john.doe @other/sub/team") {
+		got = append(got, result.Message)
+	}
+	want := []string{
+		"Owner '@john.doe' has no write access to group/project",
+		"Owner '@other/sub/team' has no write access to group/project",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Want: %v, Got: %v", want, got)
+	}
+}
+
+func TestGitlabUnauthorized(t *testing.T) {
+	options := gitlabOptions()
+	options.GitlabToken = "wrong"
+	validator := Access{}.NewValidator(options)
+	got := validator.ValidateLine(1, "filepattern @developer")
+	if len(got) != 1 || got[0].CheckName != "AccessCheckFailed" {
+		t.Errorf("Want: AccessCheckFailed, Got: %v", got)
+	}
+}
+
+func TestGitlabExpandGroup(t *testing.T) {
+	api, err := newGitlabAPI(gitlabOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		group   string
+		want    []string
+		wantErr bool
+	}{
+		{group: "group", want: []string{"developer", "maintainer"}},
+		{group: "partners/devs", want: []string{"developer"}},
+		{group: "missing", wantErr: true},
+	}
+	for _, test := range tests {
+		got, err := api.ExpandGroup(test.group)
+		if (err != nil) != test.wantErr || (err == nil && !reflect.DeepEqual(got, test.want)) {
+			t.Errorf("Input: %v, Want: %v %v, Got: %v %v", test.group, test.want, test.wantErr, got, err)
+		}
+	}
+}
+
+func TestGitlabProject(t *testing.T) {
+	tests := []struct {
+		directory  string
+		gitlabURL  string
+		repository string
+		want       string
+		wantErr    string
+	}{
+		{directory: "gitlab", want: "group/subgroup/project"},
+		{directory: "gitlab", gitlabURL: "https://gitlab.com/api/v4", want: "group/subgroup/project"},
+		{directory: "bad", repository: "group/subgroup/other", want: "group/subgroup/other"},
+		{directory: "gitlab", gitlabURL: "https://gitlab.example.com/api/v4",
+			wantErr: "Remote git@gitlab.com:group/subgroup/project.git is not hosted on gitlab.example.com, set the repository explicitly"},
+		{directory: ".", repository: "project", wantErr: "Invalid project 'project', expected group/name"},
+	}
+	for _, test := range tests {
+		api, err := newGitlabAPI(codeowners.ValidatorOptions{
+			Directory:   test.directory,
+			GitlabURL:   test.gitlabURL,
+			GitlabToken: "token",
+			Repository:  test.repository,
+		})
+		if len(test.wantErr) > 0 {
+			if err == nil || err.Error() != test.wantErr {
+				t.Errorf("Input: %v, Want: %v, Got: %v", test, test.wantErr, err)
+			}
+			continue
+		}
+		if err != nil || api.Project() != test.want {
+			t.Errorf("Input: %v, Want: %v, Got: %v", test, test.want, err)
+		}
+	}
+}
+
+func TestUnknownPlatform(t *testing.T) {
+	options := gitlabOptions()
+	options.Platform = "svn"
+	validator := Access{}.NewValidator(options).(*accessValidator)
+	got := append(validator.ValidateLine(1, "filepattern @developer"), validator.Finish()...)
+	if len(got) != 1 || got[0].Message != "Could not check access of owners: Unknown platform 'svn'" {
+		t.Errorf("Want: Unknown platform, Got: %v", got)
+	}
+}
@@ -18,10 +18,19 @@ func init() {
 type InvalidOwner struct {
 }
 
+// Describe returns metadata about this checker
+func (c InvalidOwner) Describe() codeowners.CheckerInfo {
+	return codeowners.CheckerInfo{
+		Description: "Owners must be valid users, teams or emails",
+		Severity:    codeowners.Error,
+	}
+}
+
 // NewValidator returns validating capabilities for this checker
 func (c InvalidOwner) NewValidator(options codeowners.ValidatorOptions) codeowners.Validator {
 	return invalidOwnerValidator{
 		options: options,
+		dialect: codeowners.PlatformDialect(options.Platform),
 	}
 }
 
@@ -50,32 +59,116 @@ func ownerValid(owner string) bool {
 	return true
 }
 
+// gitlabPathExpr matches GitLab usernames and the path of each group, subgroup or project of a nested path
+var gitlabPathExpr = regexp.MustCompile("^[A-Za-z0-9_][A-Za-z0-9_.-]*$")
+
+// gitlabOwnerValid validates a GitLab @username, a @group/subgroup path nested any number of times or an email
+func gitlabOwnerValid(owner string) bool {
+	if !strings.HasPrefix(owner, "@") {
+		return ownerValid(owner) // emails
+	}
+	for _, path := range strings.Split(owner[1:], "/") {
+		if len(path) > 255 || !gitlabPathExpr.MatchString(path) ||
+			strings.HasSuffix(path, ".") || strings.HasSuffix(path, ".git") || strings.HasSuffix(path, ".atom") {
+			return false
+		}
+	}
+	return true
+}
+
+// giteaNameExpr matches Gitea user and organization names, '.', '_' and '-' may only separate alphanumerics
+var giteaNameExpr = regexp.MustCompile("^[A-Za-z0-9](?:[._-]?[A-Za-z0-9])*$")
+
+// giteaTeamExpr matches Gitea team names
+var giteaTeamExpr = regexp.MustCompile("^[A-Za-z0-9_.-]+$")
+
+// giteaOwnerValid validates a Gitea @user, an @org/team or an email
+func giteaOwnerValid(owner string) bool {
+	if !strings.HasPrefix(owner, "@") {
+		return ownerValid(owner) // emails
+	}
+	parts := strings.Split(owner[1:], "/")
+	if len(parts) > 2 || len(parts[0]) > 40 || !giteaNameExpr.MatchString(parts[0]) {
+		return false
+	}
+	return len(parts) == 1 || giteaTeamExpr.MatchString(parts[1])
+}
+
+// bitbucketNameExpr matches Bitbucket user and group slugs
+var bitbucketNameExpr = regexp.MustCompile("^[A-Za-z0-9._-]+$")
+
+// dialectOwnerValid validates owner following dialect, Bitbucket owners may also be @@groups with a selection strategy,
+// the error tells why the owner is malformed when it can be told
+func dialectOwnerValid(owner string, dialect codeowners.Dialect) (bool, error) {
+	switch dialect {
+	case codeowners.GitlabDialect:
+		return gitlabOwnerValid(owner), nil
+	case codeowners.GiteaDialect:
+		return giteaOwnerValid(owner), nil
+	case codeowners.BitbucketDialect:
+	default:
+		return ownerValid(owner), nil
+	}
+	parsed, err := codeowners.ParseOwner(owner, dialect)
+	if err != nil {
+		return false, err
+	}
+	if parsed.Group {
+		return bitbucketNameExpr.MatchString(strings.TrimPrefix(parsed.Name, "@@")), nil
+	}
+	if strings.HasPrefix(parsed.Name, "@") && !strings.HasPrefix(parsed.Name, "@@") {
+		return bitbucketNameExpr.MatchString(parsed.Name[1:]), nil
+	}
+	return ownerValid(parsed.Name), nil
+}
+
+// suggestOwner tries to correct common mistakes, such as a missing '@' or a trailing separator
+func suggestOwner(owner string, dialect codeowners.Dialect) (string, bool) {
+	candidate := strings.TrimRight(owner, ",;")
+	if len(candidate) > 0 && !strings.Contains(candidate, "@") {
+		candidate = "@" + candidate
+	}
+	if candidate == owner || len(candidate) == 0 {
+		return "", false
+	}
+	if valid, _ := dialectOwnerValid(candidate, dialect); valid {
+		return candidate, true
+	}
+	return "", false
+}
+
 type invalidOwnerValidator struct {
 	options codeowners.ValidatorOptions
+	dialect codeowners.Dialect
 }
 
 // ValidateLine runs this InvalidOwner's check against each line
 func (v invalidOwnerValidator) ValidateLine(lineNo int, line string) []codeowners.CheckResult {
 	var results []codeowners.CheckResult
 
-	_, owners := codeowners.ParseLine(line)
+	node := codeowners.ParseDialectNode(lineNo, line, v.dialect)
 
-	for _, owner := range owners {
-		if ownerValid(owner) {
+	for _, owner := range node.Owners {
+		valid, err := dialectOwnerValid(owner.Value, v.dialect)
+		if valid {
 			continue
 		}
+		message := fmt.Sprintf("Owner '%s' is invalid", owner.Value)
+		if err != nil {
+			message = fmt.Sprintf("Owner '%s' is invalid: %v", owner.Value, err)
+		}
 		result := codeowners.CheckResult{
-			Position: codeowners.Position{
-				FilePath:    v.options.CodeownersFileLocation,
-				StartLine:   lineNo,
-				EndLine:     lineNo,
-				StartColumn: strings.Index(line, owner) + 1,
-			},
-			Message:   fmt.Sprintf("Owner '%s' is invalid", owner),
+			Position:  owner.Span.Position(v.options.CodeownersFileLocation),
+			Message:   message,
 			Severity:  codeowners.Error,
 			CheckName: invalidOwnerCheckerName,
 		}
-		result.Position.EndColumn = result.Position.StartColumn + len(owner)
+		if suggestion, ok := suggestOwner(owner.Value, v.dialect); ok {
+			result.SuggestedFix = &codeowners.SuggestedFix{
+				Message: fmt.Sprintf("Replace with '%s'", suggestion),
+				Edits:   []codeowners.TextEdit{codeowners.ReplaceSpan(result.Position, suggestion)},
+			}
+		}
 
 		if results == nil {
 			results = []codeowners.CheckResult{result}
@@ -62,6 +62,21 @@ func TestInvalidOwnerCheckInvalidNoAt(t *testing.T) {
 			Message:   "Owner 'invalid-owner' is invalid",
 			Severity:  codeowners.Error,
 			CheckName: "InvalidOwner",
+			SuggestedFix: &codeowners.SuggestedFix{
+				Message: "Replace with '@invalid-owner'",
+				Edits: []codeowners.TextEdit{
+					{
+						Position: codeowners.Position{
+							FilePath:    "CODEOWNERS",
+							StartLine:   1,
+							StartColumn: 13,
+							EndLine:     1,
+							EndColumn:   26,
+						},
+						NewText: "@invalid-owner",
+					},
+				},
+			},
 		},
 	}
 
@@ -197,6 +212,21 @@ func TestInvalidOwnerCheckMultipleInvalid(t *testing.T) {
 			Message:   "Owner 'invalid-owner' is invalid",
 			Severity:  codeowners.Error,
 			CheckName: "InvalidOwner",
+			SuggestedFix: &codeowners.SuggestedFix{
+				Message: "Replace with '@invalid-owner'",
+				Edits: []codeowners.TextEdit{
+					{
+						Position: codeowners.Position{
+							FilePath:    "CODEOWNERS",
+							StartLine:   1,
+							StartColumn: 13,
+							EndLine:     1,
+							EndColumn:   26,
+						},
+						NewText: "@invalid-owner",
+					},
+				},
+			},
 		},
 		{
 			Position: codeowners.Position{
@@ -209,6 +239,21 @@ func TestInvalidOwnerCheckMultipleInvalid(t *testing.T) {
 			Message:   "Owner 'another-invalid-owner' is invalid",
 			Severity:  codeowners.Error,
 			CheckName: "InvalidOwner",
+			SuggestedFix: &codeowners.SuggestedFix{
+				Message: "Replace with '@another-invalid-owner'",
+				Edits: []codeowners.TextEdit{
+					{
+						Position: codeowners.Position{
+							FilePath:    "CODEOWNERS",
+							StartLine:   1,
+							StartColumn: 27,
+							EndLine:     1,
+							EndColumn:   48,
+						},
+						NewText: "@another-invalid-owner",
+					},
+				},
+			},
 		},
 	}
 
@@ -282,3 +327,143 @@ func TestInvalidOwnerCheckPassUserOrg(t *testing.T) {
 		t.Errorf("Input: %v, Want: %v, Got: %v", input, nil, got)
 	}
 }
+
+func TestInvalidOwnerCheckOwnerInPattern(t *testing.T) {
+	input := struct {
+		lineNo int
+		line   string
+	}{
+		lineNo: 1,
+		line:   "docs/ docs",
+	}
+	want := []codeowners.CheckResult{
+		{
+			Position: codeowners.Position{
+				FilePath:    "CODEOWNERS",
+				StartLine:   1,
+				StartColumn: 7,
+				EndLine:     1,
+				EndColumn:   11,
+			},
+			Message:   "Owner 'docs' is invalid",
+			Severity:  codeowners.Error,
+			CheckName: "InvalidOwner",
+			SuggestedFix: &codeowners.SuggestedFix{
+				Message: "Replace with '@docs'",
+				Edits: []codeowners.TextEdit{
+					{
+						Position: codeowners.Position{
+							FilePath:    "CODEOWNERS",
+							StartLine:   1,
+							StartColumn: 7,
+							EndLine:     1,
+							EndColumn:   11,
+						},
+						NewText: "@docs",
+					},
+				},
+			},
+		},
+	}
+
+	checker := checkers.InvalidOwner{}
+	validator := checker.NewValidator(codeowners.ValidatorOptions{
+		Directory:              ".",
+		CodeownersFileLocation: "CODEOWNERS",
+	})
+	got := validator.ValidateLine(input.lineNo, input.line)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Input: %v, Want: %v, Got: %v", input, want, got)
+	}
+}
+
+func TestInvalidOwnerCheckBitbucket(t *testing.T) {
+	tests := []struct {
+		platform string
+		line     string
+		want     []string
+	}{
+		{platform: "", line: "filepattern @@group:random(2)", want: []string{"Owner '@@group:random(2)' is invalid"}},
+		{platform: "bitbucket", line: "filepattern @@group @user.name user@example.com", want: nil},
+		{platform: "bitbucket", line: "filepattern @@group:random(2) @@group:least_busy(1)", want: nil},
+		{platform: "bitbucket", line: "@@@group @alice @bob", want: nil},
+		{platform: "bitbucket", line: "filepattern @@group:random(0)", want: []string{"Owner '@@group:random(0)' is invalid: invalid number of reviewers '0'"}},
+		{platform: "bitbucket", line: "filepattern @@@group", want: []string{"Owner '@@@group' is invalid"}},
+		{platform: "bitbucket", line: "filepattern @user/team", want: []string{"Owner '@user/team' is invalid"}},
+	}
+	for _, test := range tests {
+		validator := checkers.InvalidOwner{}.NewValidator(codeowners.ValidatorOptions{
+			Directory:              ".",
+			CodeownersFileLocation: "CODEOWNERS",
+			Platform:               test.platform,
+		})
+		var got []string
+		for _, result := range validator.ValidateLine(1, test.line) {
+			got = append(got, result.Message)
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("Input: %v, Want: %v, Got: %v", test.line, test.want, got)
+		}
+	}
+}
+
+func TestInvalidOwnerCheckGitlab(t *testing.T) {
+	tests := []struct {
+		platform string
+		line     string
+		want     []string
+	}{
+		{platform: "", line: "filepattern @group/sub/team", want: []string{"Owner '@group/sub/team' is invalid"}},
+		{platform: "", line: "filepattern @john.doe", want: []string{"Owner '@john.doe' is invalid"}},
+		{platform: "gitlab", line: "filepattern @group/sub/team @john.doe @jane_doe @_bot dev@example.com", want: nil},
+		{platform: "gitlab", line: "filepattern @group/sub-group/my.project", want: nil},
+		{platform: "gitlab", line: "filepattern @-user", want: []string{"Owner '@-user' is invalid"}},
+		{platform: "gitlab", line: "filepattern @user.", want: []string{"Owner '@user.' is invalid"}},
+		{platform: "gitlab", line: "filepattern @group/project.git", want: []string{"Owner '@group/project.git' is invalid"}},
+		{platform: "gitlab", line: "filepattern @group//team", want: []string{"Owner '@group//team' is invalid"}},
+	}
+	for _, test := range tests {
+		validator := checkers.InvalidOwner{}.NewValidator(codeowners.ValidatorOptions{
+			Directory:              ".",
+			CodeownersFileLocation: "CODEOWNERS",
+			Platform:               test.platform,
+		})
+		var got []string
+		for _, result := range validator.ValidateLine(1, test.line) {
+			got = append(got, result.Message)
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("Input: %v %v, Want: %v, Got: %v", test.platform, test.line, test.want, got)
+		}
+	}
+}
+
+func TestInvalidOwnerCheckGitea(t *testing.T) {
+	tests := []struct {
+		platform string
+		line     string
+		want     []string
+	}{
+		{platform: "", line: "filepattern @jane_doe", want: []string{"Owner '@jane_doe' is invalid"}},
+		{platform: "gitea", line: "filepattern @jane_doe @john.doe @a-b.c_d @org/team_1 @org/Team.Name dev@example.com", want: nil},
+		{platform: "gitea", line: "filepattern @_jane", want: []string{"Owner '@_jane' is invalid"}},
+		{platform: "gitea", line: "filepattern @john..doe", want: []string{"Owner '@john..doe' is invalid"}},
+		{platform: "gitea", line: "filepattern @john.", want: []string{"Owner '@john.' is invalid"}},
+		{platform: "gitea", line: "filepattern @org/sub/team", want: []string{"Owner '@org/sub/team' is invalid"}},
+		{platform: "gitea", line: "filepattern @org/", want: []string{"Owner '@org/' is invalid"}},
+	}
+	for _, test := range tests {
+		validator := checkers.InvalidOwner{}.NewValidator(codeowners.ValidatorOptions{
+			Directory:              ".",
+			CodeownersFileLocation: "CODEOWNERS",
+			Platform:               test.platform,
+		})
+		var got []string
+		for _, result := range validator.ValidateLine(1, test.line) {
+			got = append(got, result.Message)
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("Input: %v %v, Want: %v, Got: %v", test.platform, test.line, test.want, got)
+		}
+	}
+}
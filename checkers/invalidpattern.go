@@ -0,0 +1,59 @@
+package checkers
+
+import (
+	"fmt"
+
+	"github.com/fmenezes/codeowners"
+)
+
+const invalidPatternCheckerName string = "InvalidPattern"
+
+func init() {
+	codeowners.RegisterChecker(invalidPatternCheckerName, InvalidPattern{})
+}
+
+// InvalidPattern represents checker to find patterns that do not compile in the dialect of the platform,
+// such as invalid regular expressions on Gitea, as the platform ignores those rules
+type InvalidPattern struct{}
+
+// Describe returns metadata about this checker
+func (c InvalidPattern) Describe() codeowners.CheckerInfo {
+	return codeowners.CheckerInfo{
+		Description: "Patterns must compile in the dialect of the platform",
+		Severity:    codeowners.Error,
+	}
+}
+
+// NewValidator returns validating capabilities for this checker
+func (c InvalidPattern) NewValidator(options codeowners.ValidatorOptions) codeowners.Validator {
+	return invalidPatternValidator{
+		options: options,
+		dialect: codeowners.PlatformDialect(options.Platform),
+	}
+}
+
+type invalidPatternValidator struct {
+	options codeowners.ValidatorOptions
+	dialect codeowners.Dialect
+}
+
+// ValidateLine runs this InvalidPattern's check against each line
+func (v invalidPatternValidator) ValidateLine(lineNo int, line string) []codeowners.CheckResult {
+	node := codeowners.ParseDialectNode(lineNo, line, v.dialect)
+	if node.Kind != codeowners.RuleNode {
+		return nil
+	}
+
+	_, err := codeowners.CompilePattern(node.Pattern.Value, v.dialect)
+	if err == nil {
+		return nil
+	}
+	return []codeowners.CheckResult{
+		{
+			Position:  node.Pattern.Span.Position(v.options.CodeownersFileLocation),
+			Message:   fmt.Sprintf("Pattern '%s' is invalid in the %s dialect: %v", node.Pattern.Value, v.dialect.Name(), err),
+			Severity:  codeowners.Error,
+			CheckName: invalidPatternCheckerName,
+		},
+	}
+}
@@ -0,0 +1,45 @@
+package checkers_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/fmenezes/codeowners"
+	"github.com/fmenezes/codeowners/checkers"
+)
+
+func TestInvalidPatternCheck(t *testing.T) {
+	tests := []struct {
+		platform string
+		line     string
+		want     []codeowners.CheckResult
+	}{
+		{platform: "", line: "docs/[z-a] @owner", want: nil},
+		{platform: "gitea", line: `docs/.*\.md @owner`, want: nil},
+		{platform: "gitea", line: `!.*\.go @owner`, want: nil},
+		{platform: "gitea", line: "# docs/[z-a]", want: nil},
+		{
+			platform: "gitea",
+			line:     "docs/[z-a] @owner",
+			want: []codeowners.CheckResult{
+				{
+					Position:  codeowners.Position{FilePath: "CODEOWNERS", StartLine: 1, StartColumn: 1, EndLine: 1, EndColumn: 11},
+					Message:   "Pattern 'docs/[z-a]' is invalid in the Gitea dialect: error parsing regexp: invalid character class range: `z-a`",
+					Severity:  codeowners.Error,
+					CheckName: "InvalidPattern",
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		validator := checkers.InvalidPattern{}.NewValidator(codeowners.ValidatorOptions{
+			Directory:              ".",
+			CodeownersFileLocation: "CODEOWNERS",
+			Platform:               test.platform,
+		})
+		got := validator.ValidateLine(1, test.line)
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("Input: %v, Want: %v, Got: %v", test, test.want, got)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package checkers
+
+import (
+	"fmt"
+
+	"github.com/fmenezes/codeowners"
+)
+
+const invalidSectionCheckerName string = "InvalidSection"
+
+func init() {
+	codeowners.RegisterChecker(invalidSectionCheckerName, InvalidSection{})
+}
+
+// InvalidSection represents checker to find malformed section headers, such as [Section without its closing bracket
+// or [Section][0], in dialects with sections such as GitLab's
+type InvalidSection struct{}
+
+// Describe returns metadata about this checker
+func (c InvalidSection) Describe() codeowners.CheckerInfo {
+	return codeowners.CheckerInfo{
+		Description: "Section headers must be well formed",
+		Severity:    codeowners.Error,
+	}
+}
+
+// NewValidator returns validating capabilities for this checker
+func (c InvalidSection) NewValidator(options codeowners.ValidatorOptions) codeowners.Validator {
+	return invalidSectionValidator{
+		options: options,
+		dialect: codeowners.PlatformDialect(options.Platform),
+	}
+}
+
+type invalidSectionValidator struct {
+	options codeowners.ValidatorOptions
+	dialect codeowners.Dialect
+}
+
+// ValidateLine runs this InvalidSection's check against each line
+func (v invalidSectionValidator) ValidateLine(lineNo int, line string) []codeowners.CheckResult {
+	node := codeowners.ParseDialectNode(lineNo, line, v.dialect)
+	if node.Kind != codeowners.SectionNode || len(node.Section.Invalid) == 0 {
+		return nil
+	}
+
+	return []codeowners.CheckResult{
+		{
+			Position:  node.Pattern.Span.Position(v.options.CodeownersFileLocation),
+			Message:   fmt.Sprintf("Section header '%s' is invalid: %s", node.Pattern.Value, node.Section.Invalid),
+			Severity:  codeowners.Error,
+			CheckName: invalidSectionCheckerName,
+		},
+	}
+}
@@ -0,0 +1,44 @@
+package checkers_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/fmenezes/codeowners"
+	"github.com/fmenezes/codeowners/checkers"
+)
+
+func TestInvalidSectionCheck(t *testing.T) {
+	tests := []struct {
+		platform string
+		line     string
+		want     []codeowners.CheckResult
+	}{
+		{platform: "", line: "[Section", want: nil},
+		{platform: "gitlab", line: "^[Section][2] @owner", want: nil},
+		{platform: "gitlab", line: "docs/ @owner", want: nil},
+		{
+			platform: "gitlab",
+			line:     "[Section][0] @owner",
+			want: []codeowners.CheckResult{
+				{
+					Position:  codeowners.Position{FilePath: "CODEOWNERS", StartLine: 1, StartColumn: 1, EndLine: 1, EndColumn: 13},
+					Message:   "Section header '[Section][0]' is invalid: invalid approval count '0'",
+					Severity:  codeowners.Error,
+					CheckName: "InvalidSection",
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		validator := checkers.InvalidSection{}.NewValidator(codeowners.ValidatorOptions{
+			Directory:              ".",
+			CodeownersFileLocation: "CODEOWNERS",
+			Platform:               test.platform,
+		})
+		got := validator.ValidateLine(1, test.line)
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("Input: %v, Want: %v, Got: %v", test, test.want, got)
+		}
+	}
+}
@@ -11,24 +11,40 @@ func init() {
 // NoOwner represents checker to decide validate presence of owners in each of CODEOWNERS lines
 type NoOwner struct{}
 
+// Describe returns metadata about this checker
+func (c NoOwner) Describe() codeowners.CheckerInfo {
+	return codeowners.CheckerInfo{
+		Description: "Rules must specify at least one owner",
+		Severity:    codeowners.Error,
+	}
+}
+
 // NewValidator returns validating capabilities for this checker
 func (c NoOwner) NewValidator(options codeowners.ValidatorOptions) codeowners.Validator {
-	return noOwnerValidator{
+	return &noOwnerValidator{
 		options: options,
+		dialect: codeowners.PlatformDialect(options.Platform),
 	}
 }
 
 type noOwnerValidator struct {
-	options codeowners.ValidatorOptions
+	options       codeowners.ValidatorOptions
+	dialect       codeowners.Dialect
+	defaultOwners bool // defaultOwners is true while validating the rules of a section with default owners
 }
 
-// ValidateLine runs this NoOwner's check against each line
-func (v noOwnerValidator) ValidateLine(lineNo int, line string) []codeowners.CheckResult {
+// ValidateLine runs this NoOwner's check against each line,
+// lines are expected in order as rules without owners are owned by the default owners of their section
+func (v *noOwnerValidator) ValidateLine(lineNo int, line string) []codeowners.CheckResult {
 	var results []codeowners.CheckResult
 
-	_, owners := codeowners.ParseLine(line)
+	node := codeowners.ParseDialectNode(lineNo, line, v.dialect)
+	if node.Kind == codeowners.SectionNode {
+		v.defaultOwners = len(node.Owners) > 0
+		return nil
+	}
 
-	if len(owners) == 0 {
+	if node.Kind == codeowners.RuleNode && len(node.Owners) == 0 && !v.defaultOwners {
 		results = []codeowners.CheckResult{
 			{
 				Position: codeowners.Position{
@@ -41,3 +41,37 @@ func TestNoOwnerCheck(t *testing.T) {
 		t.Errorf("Input: %v, Want: %v, Got: %v", input, want, got)
 	}
 }
+
+func TestNoOwnerCheckSkipsCommentsAndBlankLines(t *testing.T) {
+	checker := checkers.NoOwner{}
+	validator := checker.NewValidator(codeowners.ValidatorOptions{
+		Directory:              ".",
+		CodeownersFileLocation: "CODEOWNERS",
+	})
+	for i, line := range []string{"# comment", "", "   "} {
+		got := validator.ValidateLine(i+1, line)
+		if got != nil {
+			t.Errorf("Input: %q, Want: %v, Got: %v", line, nil, got)
+		}
+	}
+}
+
+func TestNoOwnerCheckSections(t *testing.T) {
+	checker := checkers.NoOwner{}
+	validator := checker.NewValidator(codeowners.ValidatorOptions{
+		Directory:              ".",
+		CodeownersFileLocation: "CODEOWNERS",
+		Platform:               codeowners.GitlabPlatform,
+	})
+	lines := []string{"[Documentation] @docs-team", "docs/", "[Backend]", "*.go", "[Section Name]"}
+	var got []int
+	for i, line := range lines {
+		for _, result := range validator.ValidateLine(i+1, line) {
+			got = append(got, result.Position.StartLine)
+		}
+	}
+	want := []int{4}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Input: %v, Want: %v, Got: %v", lines, want, got)
+	}
+}
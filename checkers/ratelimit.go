@@ -0,0 +1,62 @@
+package checkers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/go-github/v32/github"
+)
+
+// defaultRetryBudget is how many times an accessAPI retries rate limited requests, shared by all of its lookups
+const defaultRetryBudget int32 = 10
+
+// maxRateLimitWait is the longest wait for a rate limit to clear, longer ones fail straight away
+const maxRateLimitWait time.Duration = time.Minute
+
+// defaultSecondaryWait is how long to wait for a secondary rate limit when Github does not send Retry-After
+const defaultSecondaryWait time.Duration = time.Minute
+
+// sleepContext waits for d or until ctx is done, whichever comes first
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// rateLimitWait tells whether a request was rate limited and how long to wait before retrying it.
+// Primary rate limits wait until the limit resets, secondary ones as long as Retry-After says.
+func rateLimitWait(resp *github.Response, err error, now time.Time) (time.Duration, bool) {
+	var rateLimitErr *github.RateLimitError
+	if errors.As(err, &rateLimitErr) {
+		wait := rateLimitErr.Rate.Reset.Time.Sub(now) + time.Second
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+
+	var abuseErr *github.AbuseRateLimitError
+	if errors.As(err, &abuseErr) {
+		if abuseErr.RetryAfter != nil {
+			return *abuseErr.RetryAfter, true
+		}
+		return defaultSecondaryWait, true
+	}
+
+	if err != nil && resp != nil && resp.StatusCode == http.StatusTooManyRequests {
+		if seconds, parseErr := strconv.Atoi(resp.Header.Get("Retry-After")); parseErr == nil && seconds >= 0 {
+			return time.Duration(seconds) * time.Second, true
+		}
+		return defaultSecondaryWait, true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,86 @@
+package checkers
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// defaultRemote is the git remote the repository is resolved from when none is given
+const defaultRemote string = "origin"
+
+var repoOwnerExpr = regexp.MustCompile(`^[A-Za-z0-9](?:[A-Za-z0-9-]*[A-Za-z0-9])?$`)
+var repoNameExpr = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+
+// scpRemoteExpr matches scp-like remotes, such as git@github.com:owner/repo.git
+var scpRemoteExpr = regexp.MustCompile(`^(?:[^@/]+@)?([^:/]+):(.+)$`)
+
+// redactRemote hides the credentials of a remote URL, so it can be shown in results
+func redactRemote(remote string) string {
+	if !strings.Contains(remote, "://") {
+		return remote
+	}
+	u, err := url.Parse(remote)
+	if err != nil {
+		return "<invalid URL>"
+	}
+	u.User = nil
+	return u.String()
+}
+
+// parseRemoteURL extracts the host, owner and name of the repository of a git remote URL,
+// both scp-like (git@host:owner/repo.git) and URL (ssh://, https://, git://) remotes are supported
+func parseRemoteURL(remote string) (host, owner, name string, err error) {
+	host, path, err := splitRemoteURL(remote)
+	if err != nil {
+		return "", "", "", err
+	}
+	owner, name, err = parseRepository(path)
+	if err != nil {
+		return "", "", "", fmt.Errorf("Could not find the repository in remote %s", redactRemote(remote))
+	}
+	return host, owner, name, nil
+}
+
+// splitRemoteURL splits a git remote URL into its host and the path of the repository, without the .git suffix
+func splitRemoteURL(remote string) (host, path string, err error) {
+	if strings.Contains(remote, "://") {
+		u, parseErr := url.Parse(remote)
+		if parseErr != nil {
+			return "", "", fmt.Errorf("Invalid remote %s", redactRemote(remote))
+		}
+		host, path = u.Hostname(), u.Path
+	} else if data := scpRemoteExpr.FindStringSubmatch(remote); data != nil {
+		host, path = data[1], data[2]
+	}
+	if len(host) == 0 {
+		return "", "", fmt.Errorf("Could not find the host in remote %s", redactRemote(remote))
+	}
+	return host, strings.TrimSuffix(strings.Trim(path, "/"), ".git"), nil
+}
+
+// parseRepository splits a repository given as owner/name
+func parseRepository(repository string) (owner, name string, err error) {
+	parts := strings.Split(repository, "/")
+	if len(parts) != 2 || !repoOwnerExpr.MatchString(parts[0]) || !repoNameExpr.MatchString(parts[1]) ||
+		parts[1] == "." || parts[1] == ".." {
+		return "", "", fmt.Errorf("Invalid repository '%s', expected owner/name", repository)
+	}
+	return parts[0], parts[1], nil
+}
+
+// parseProjectPath validates the full path of a GitLab project, a project nested in any number of groups
+// such as group/subgroup/name
+func parseProjectPath(project string) (string, error) {
+	parts := strings.Split(project, "/")
+	if len(parts) < 2 {
+		return "", fmt.Errorf("Invalid project '%s', expected group/name", project)
+	}
+	for _, part := range parts {
+		if !repoNameExpr.MatchString(part) || part == "." || part == ".." {
+			return "", fmt.Errorf("Invalid project '%s', expected group/name", project)
+		}
+	}
+	return project, nil
+}
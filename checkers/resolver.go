@@ -0,0 +1,103 @@
+package checkers
+
+import (
+	"fmt"
+
+	"github.com/fmenezes/codeowners"
+)
+
+// IdentityKind tells what kind of account an owner refers to
+type IdentityKind int
+
+// All possible kinds of identities
+const (
+	UnknownIdentity IdentityKind = iota // UnknownIdentity is an owner matching no account, such as an email no user made public
+	UserIdentity                        // UserIdentity is a single user
+	GroupIdentity                       // GroupIdentity is a Github or Gitea team, or a GitLab group
+)
+
+// Identity is the account an owner refers to on the platform hosting the repository
+type Identity struct {
+	Kind IdentityKind
+	Name string // Name is the login of users and the full path of groups, such as org/team
+	ID   int64  // ID is the numeric id of the account on platforms addressing accounts by id, zero otherwise
+}
+
+// OwnerResolver looks up the owners of a CODEOWNERS file on the platform hosting the repository,
+// the Access checker validates owners through it
+type OwnerResolver interface {
+	// Project returns the repository checked, such as owner/name or group/subgroup/name
+	Project() string
+	// ResolveIdentity returns the account owner refers to, owner is a @user, a @org/team, a @group or an email
+	ResolveIdentity(owner string) (Identity, error)
+	// Permission returns the access level identity has to the project: admin, maintain, write or push, triage, read or pull, or none
+	Permission(identity Identity) (string, error)
+	// ExpandGroup lists the logins of the members of a group given by its full path
+	ExpandGroup(group string) ([]string, error)
+}
+
+// hasCredentials tells whether the platform of options can be called, Github with a token or an App, GitLab and Gitea with a token.
+// Owners are not looked up on Bitbucket, so it is false for it. Unknown platforms are reported as failures rather than skipped, so it is true for them.
+func hasCredentials(options codeowners.ValidatorOptions) bool {
+	switch options.Platform {
+	case "", codeowners.GithubPlatform:
+		return hasGithubCredentials(options)
+	case codeowners.GitlabPlatform:
+		return len(options.GitlabToken) > 0
+	case codeowners.GiteaPlatform:
+		return len(options.GiteaToken) > 0
+	case codeowners.BitbucketPlatform:
+		return false
+	}
+	return true
+}
+
+// newOwnerResolver returns the resolver of the platform of options along with the host it calls
+func newOwnerResolver(options codeowners.ValidatorOptions) (OwnerResolver, string, error) {
+	switch options.Platform {
+	case "", codeowners.GithubPlatform:
+		api, err := newAccessAPI(options)
+		if err != nil {
+			return nil, "", err
+		}
+		return api, api.repoHost, nil
+	case codeowners.GitlabPlatform:
+		api, err := newGitlabAPI(options)
+		if err != nil {
+			return nil, "", err
+		}
+		return api, api.host(), nil
+	case codeowners.GiteaPlatform:
+		api, err := newGiteaAPI(options)
+		if err != nil {
+			return nil, "", err
+		}
+		return api, api.host(), nil
+	}
+	return nil, "", fmt.Errorf("Unknown platform '%s'", options.Platform)
+}
+
+// resolverSource checks owners through an OwnerResolver, owners matching no account are let through
+// as platforms do not tell which user an email belongs to unless it was made public
+type resolverSource struct {
+	resolver OwnerResolver
+}
+
+func (s resolverSource) repository() string {
+	return s.resolver.Project()
+}
+
+func (s resolverSource) ownerHasWriteAccess(owner string) (bool, error) {
+	identity, err := s.resolver.ResolveIdentity(owner)
+	if err != nil {
+		return false, err
+	}
+	if identity.Kind == UnknownIdentity {
+		return true, nil
+	}
+	permission, err := s.resolver.Permission(identity)
+	if err != nil {
+		return false, err
+	}
+	return hasWriteAccess(permission), nil
+}
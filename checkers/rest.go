@@ -0,0 +1,97 @@
+package checkers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// restClient calls the JSON REST API of platforms without a dedicated client library, such as GitLab and Gitea
+type restClient struct {
+	baseURL       *url.URL
+	authorization string // authorization is the value of the Authorization header sent with every request
+	client        *http.Client
+	ctx           context.Context
+}
+
+// newRESTClient returns a client of the API at baseURL, calls go through the transport of caFile and proxy, see newTransport
+func newRESTClient(ctx context.Context, baseURL, authorization, caFile, proxy string) (*restClient, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if !strings.HasSuffix(baseURL, "/") {
+		baseURL += "/"
+	}
+	u, err := url.Parse(baseURL)
+	if err != nil || len(u.Hostname()) == 0 {
+		return nil, fmt.Errorf("Invalid API URL %s", strings.TrimSuffix(baseURL, "/"))
+	}
+
+	transport, err := newTransport(caFile, proxy)
+	if err != nil {
+		return nil, err
+	}
+	return &restClient{
+		baseURL:       u,
+		authorization: authorization,
+		client:        &http.Client{Transport: transport},
+		ctx:           ctx,
+	}, nil
+}
+
+// host returns the host name of the API
+func (c *restClient) host() string {
+	return c.baseURL.Hostname()
+}
+
+// get decodes the JSON response of the endpoint at path into v, v is left untouched when the response is 404 Not Found
+func (c *restClient) get(path string, query url.Values, v interface{}) (*http.Response, error) {
+	ref, err := url.Parse(path)
+	if err != nil {
+		return nil, err
+	}
+	u := c.baseURL.ResolveReference(ref)
+	u.RawQuery = query.Encode()
+
+	req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", c.authorization)
+	req.Header.Set("Accept", "application/json")
+	resp, err := c.client.Do(req.WithContext(c.ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return resp, nil
+	case resp.StatusCode < 200 || resp.StatusCode > 299:
+		return resp, fmt.Errorf("GET %s: %s", u.String(), resp.Status)
+	}
+	return resp, json.NewDecoder(resp.Body).Decode(v)
+}
+
+// remoteRepositoryPath returns the path of the repository of the git remote, which must be hosted on host
+func remoteRepositoryPath(directory, remote, host string) (string, error) {
+	if len(remote) == 0 {
+		remote = defaultRemote
+	}
+	remoteURL, err := gitRemoteURL(directory, remote)
+	if err != nil {
+		return "", fmt.Errorf("Could not read the URL of remote '%s', set the repository explicitly: %v", remote, err)
+	}
+	remoteHost, path, err := splitRemoteURL(remoteURL)
+	if err != nil {
+		return "", fmt.Errorf("%v, set the repository explicitly", err)
+	}
+	if !strings.EqualFold(remoteHost, host) {
+		return "", fmt.Errorf("Remote %s is not hosted on %s, set the repository explicitly", redactRemote(remoteURL), host)
+	}
+	return path, nil
+}
@@ -0,0 +1,271 @@
+package checkers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fmenezes/codeowners"
+	"github.com/google/go-github/v32/github"
+	"gopkg.in/yaml.v2"
+)
+
+// Snapshot is an offline copy of the access users and teams have to a repository,
+// the Access checker validates owners against it when AccessSnapshotOption is set
+type Snapshot struct {
+	Repository string         `json:"repository" yaml:"repository"` // Repository is the repository as owner/name
+	TakenAt    time.Time      `json:"takenAt" yaml:"takenAt"`
+	Users      []SnapshotUser `json:"users" yaml:"users"`
+	Teams      []SnapshotTeam `json:"teams" yaml:"teams"`
+}
+
+// SnapshotUser is a user and its permission to the repository
+type SnapshotUser struct {
+	Login      string   `json:"login" yaml:"login"`
+	Emails     []string `json:"emails,omitempty" yaml:"emails,omitempty"` // Emails are the emails used as owners that belong to this user
+	Permission string   `json:"permission" yaml:"permission"`             // Permission is admin, maintain, push, triage, pull or none
+}
+
+// SnapshotTeam is a team, its permission to the repository and its members
+type SnapshotTeam struct {
+	Slug       string   `json:"slug" yaml:"slug"` // Slug is the team as org/team
+	Permission string   `json:"permission" yaml:"permission"`
+	Members    []string `json:"members,omitempty" yaml:"members,omitempty"`
+}
+
+// isJSON tells whether file holds JSON, other files are read and written as YAML
+func isJSON(file string) bool {
+	return strings.EqualFold(filepath.Ext(file), ".json")
+}
+
+// LoadSnapshot reads a snapshot from a JSON or a YAML file, depending on its extension
+func LoadSnapshot(file string) (*Snapshot, error) {
+	content, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	snapshot := &Snapshot{}
+	if isJSON(file) {
+		err = json.Unmarshal(content, snapshot)
+	} else {
+		err = yaml.Unmarshal(content, snapshot)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Invalid snapshot %s: %v", file, err)
+	}
+	return snapshot, nil
+}
+
+// checkRepository fails when the platform is not Github, or when the snapshot was not taken of the repository
+// being checked, the one given explicitly or else the one of the git remote. Nothing is compared when there is no remote to resolve the repository from.
+func (s *Snapshot) checkRepository(options codeowners.ValidatorOptions) error {
+	if len(options.Platform) > 0 && options.Platform != codeowners.GithubPlatform {
+		return fmt.Errorf("Snapshots are only supported on Github, not on %s", options.Platform)
+	}
+	a := &accessAPI{directory: options.Directory, baseURL: options.GithubURL, remote: options.Remote, explicitRepo: options.Repository}
+	if len(a.remote) == 0 {
+		a.remote = defaultRemote
+	}
+	if err := a.resolveRepository(); err != nil {
+		if len(a.explicitRepo) > 0 {
+			return err
+		}
+		return nil
+	}
+	if !strings.EqualFold(s.Repository, a.Project()) {
+		return fmt.Errorf("Snapshot of %s does not match the repository %s", s.Repository, a.Project())
+	}
+	return nil
+}
+
+// Encode writes the snapshot as JSON when format is "json" and as YAML when it is "yaml"
+func (s *Snapshot) Encode(w io.Writer, format string) error {
+	switch format {
+	case "json":
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(s)
+	case "yaml":
+		return yaml.NewEncoder(w).Encode(s)
+	}
+	return fmt.Errorf("Unknown snapshot format '%s'", format)
+}
+
+// Project returns the repository of the snapshot
+func (s *Snapshot) Project() string {
+	return s.Repository
+}
+
+// ResolveIdentity returns the user or team of a @user or @org/team owner, emails are resolved to the user they were recorded on
+func (s *Snapshot) ResolveIdentity(owner string) (Identity, error) {
+	if strings.HasPrefix(owner, "@") {
+		name := strings.TrimPrefix(owner, "@")
+		if strings.Contains(name, "/") {
+			return Identity{Kind: GroupIdentity, Name: name}, nil
+		}
+		return Identity{Kind: UserIdentity, Name: name}, nil
+	}
+	for _, user := range s.Users {
+		for _, email := range user.Emails {
+			if strings.EqualFold(email, owner) {
+				return Identity{Kind: UserIdentity, Name: user.Login}, nil
+			}
+		}
+	}
+	return Identity{Kind: UnknownIdentity, Name: owner}, nil
+}
+
+// Permission returns the recorded permission of a user or a team, none when it was not recorded
+func (s *Snapshot) Permission(identity Identity) (string, error) {
+	switch identity.Kind {
+	case UserIdentity:
+		for _, user := range s.Users {
+			if strings.EqualFold(user.Login, identity.Name) {
+				return user.Permission, nil
+			}
+		}
+	case GroupIdentity:
+		if team := s.team(identity.Name); team != nil {
+			return team.Permission, nil
+		}
+	}
+	return "none", nil
+}
+
+// ExpandGroup returns the recorded members of a team given as org/team
+func (s *Snapshot) ExpandGroup(group string) ([]string, error) {
+	if team := s.team(group); team != nil {
+		return team.Members, nil
+	}
+	return nil, fmt.Errorf("Team '%s' is not in the snapshot", group)
+}
+
+func (s *Snapshot) team(slug string) *SnapshotTeam {
+	for i := range s.Teams {
+		if strings.EqualFold(s.Teams[i].Slug, slug) {
+			return &s.Teams[i]
+		}
+	}
+	return nil
+}
+
+// TakeSnapshot records the collaborators and teams of the repository, with the team members, using the Github API.
+// Emails can not be listed, the ones given are looked up and recorded on the user they belong to.
+func TakeSnapshot(options codeowners.ValidatorOptions, emails []string) (*Snapshot, error) {
+	api, err := newAccessAPI(options)
+	if err != nil {
+		return nil, err
+	}
+	return api.snapshot(emails)
+}
+
+func (a *accessAPI) snapshot(emails []string) (*Snapshot, error) {
+	snapshot := &Snapshot{Repository: a.Project(), TakenAt: time.Now().UTC(), Users: []SnapshotUser{}, Teams: []SnapshotTeam{}}
+
+	users := make(map[string]*SnapshotUser)
+	collaboratorOptions := &github.ListCollaboratorsOptions{Affiliation: "all", ListOptions: github.ListOptions{PerPage: 100}}
+	for {
+		var collaborators []*github.User
+		resp, err := a.withRetry(func() (resp *github.Response, err error) {
+			collaborators, resp, err = a.client.Repositories.ListCollaborators(a.ctx, a.repoOwner, a.repoName, collaboratorOptions)
+			return resp, err
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, collaborator := range collaborators {
+			users[strings.ToLower(collaborator.GetLogin())] = &SnapshotUser{
+				Login:      collaborator.GetLogin(),
+				Permission: permissionLevel(collaborator.GetPermissions()),
+			}
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		collaboratorOptions.Page = resp.NextPage
+	}
+
+	for _, email := range emails {
+		login, err := a.findUserFromEmail(email)
+		if err != nil {
+			return nil, err
+		}
+		if len(login) == 0 {
+			continue
+		}
+		user, found := users[strings.ToLower(login)]
+		if !found {
+			user = &SnapshotUser{Login: login, Permission: "none"}
+			users[strings.ToLower(login)] = user
+		}
+		user.Emails = append(user.Emails, email)
+	}
+	for _, user := range users {
+		snapshot.Users = append(snapshot.Users, *user)
+	}
+	sort.Slice(snapshot.Users, func(i, j int) bool { return snapshot.Users[i].Login < snapshot.Users[j].Login })
+
+	teamOptions := &github.ListOptions{PerPage: 100}
+	for {
+		var teams []*github.Team
+		resp, err := a.withRetry(func() (resp *github.Response, err error) {
+			teams, resp, err = a.client.Repositories.ListTeams(a.ctx, a.repoOwner, a.repoName, teamOptions)
+			return resp, err
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, team := range teams {
+			org := team.GetOrganization().GetLogin()
+			if len(org) == 0 {
+				org = a.repoOwner
+			}
+			members, err := a.teamMembers(org, team.GetSlug())
+			if err != nil {
+				return nil, err
+			}
+			snapshot.Teams = append(snapshot.Teams, SnapshotTeam{
+				Slug:       fmt.Sprintf("%s/%s", org, team.GetSlug()),
+				Permission: team.GetPermission(),
+				Members:    members,
+			})
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		teamOptions.Page = resp.NextPage
+	}
+	sort.Slice(snapshot.Teams, func(i, j int) bool { return snapshot.Teams[i].Slug < snapshot.Teams[j].Slug })
+
+	return snapshot, nil
+}
+
+// teamMembers lists the logins of the members of org/team
+func (a *accessAPI) teamMembers(org, team string) ([]string, error) {
+	logins := []string{}
+	options := &github.TeamListTeamMembersOptions{ListOptions: github.ListOptions{PerPage: 100}}
+	for {
+		var members []*github.User
+		resp, err := a.withRetry(func() (resp *github.Response, err error) {
+			members, resp, err = a.client.Teams.ListTeamMembersBySlug(a.ctx, org, team, options)
+			return resp, err
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, member := range members {
+			logins = append(logins, member.GetLogin())
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		options.Page = resp.NextPage
+	}
+	sort.Strings(logins)
+	return logins, nil
+}
@@ -0,0 +1,87 @@
+// +build unit
+
+package checkers
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/fmenezes/codeowners"
+)
+
+func TestTakeSnapshot(t *testing.T) {
+	want := &Snapshot{
+		Repository: "owner/repo",
+		Users: []SnapshotUser{
+			{Login: "boss", Permission: "admin"},
+			{Login: "mojombo", Emails: []string{"someone@example.com"}, Permission: "none"},
+			{Login: "octocat", Permission: "push"},
+			{Login: "reader", Permission: "pull"},
+		},
+		Teams: []SnapshotTeam{
+			{Slug: "github/justice-league", Permission: "push", Members: []string{"octocat"}},
+			{Slug: "owner/readers", Permission: "pull", Members: []string{}},
+		},
+	}
+
+	got, err := TakeSnapshot(codeowners.ValidatorOptions{
+		Directory:   ".",
+		GithubToken: "token",
+	}, []string{"someone@example.com", "notfound@example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.TakenAt.IsZero() {
+		t.Errorf("Want: the time the snapshot was taken, Got: %v", got.TakenAt)
+	}
+	got.TakenAt = time.Time{}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Want: %v, Got: %v", want, got)
+	}
+}
+
+func TestSnapshotEncode(t *testing.T) {
+	want, err := LoadSnapshot(filepath.Join("..", "test", "fixtures", "snapshot", "snapshot.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir, err := ioutil.TempDir("", "codeowners")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	for _, format := range []string{"json", "yaml"} {
+		var output bytes.Buffer
+		err = want.Encode(&output, format)
+		if err != nil {
+			t.Fatal(err)
+		}
+		file := filepath.Join(dir, "snapshot."+format)
+		err = ioutil.WriteFile(file, output.Bytes(), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := LoadSnapshot(file)
+		if err != nil {
+			t.Fatalf("Input: %v, Want: %v, Got: %v", format, nil, err)
+		}
+		if !got.TakenAt.Equal(want.TakenAt) {
+			t.Errorf("Input: %v, Want: %v, Got: %v", format, want.TakenAt, got.TakenAt)
+		}
+		got.TakenAt = want.TakenAt
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Input: %v, Want: %v, Got: %v", format, want, got)
+		}
+	}
+
+	if err = want.Encode(&bytes.Buffer{}, "xml"); err == nil {
+		t.Errorf("Input: %v, Want: error, Got: %v", "xml", err)
+	}
+}
@@ -0,0 +1,90 @@
+package checkers
+
+import (
+	"encoding/pem"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeTempFile(t *testing.T, content []byte) string {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "codeowners")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	file := filepath.Join(dir, "file")
+	err = ioutil.WriteFile(file, content, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
+
+func TestNewTransportTrustsCAFile(t *testing.T) {
+	tlsServer := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(204)
+	}))
+	tlsServer.Config.ErrorLog = log.New(ioutil.Discard, "", 0) // the handshake rejected by the client is expected
+	tlsServer.StartTLS()
+	defer tlsServer.Close()
+
+	caFile := writeTempFile(t, pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: tlsServer.TLS.Certificates[0].Certificate[0],
+	}))
+
+	transport, err := newTransport(caFile, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := (&http.Client{Transport: transport}).Get(tlsServer.URL)
+	if err != nil {
+		t.Fatalf("Input: %v, Want: %v, Got: %v", caFile, nil, err)
+	}
+	resp.Body.Close()
+
+	transport, err = newTransport("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = (&http.Client{Transport: transport}).Get(tlsServer.URL)
+	if err == nil {
+		t.Errorf("Input: %v, Want: certificate error, Got: %v", "", err)
+	}
+}
+
+func TestNewTransportInvalidCAFile(t *testing.T) {
+	tests := []string{
+		filepath.Join("does", "not", "exist.pem"),
+		writeTempFile(t, []byte("not a certificate")),
+	}
+	for _, input := range tests {
+		_, err := newTransport(input, "")
+		if err == nil {
+			t.Errorf("Input: %v, Want: error, Got: %v", input, err)
+		}
+	}
+}
+
+func TestNewTransportProxy(t *testing.T) {
+	transport, err := newTransport("", "http://proxy.example.com:3128")
+	if err != nil {
+		t.Fatal(err)
+	}
+	req, _ := http.NewRequest("GET", "https://ghe.example.com/api/v3/", nil)
+	got, err := transport.(*http.Transport).Proxy(req)
+	if err != nil || got == nil || got.String() != "http://proxy.example.com:3128" {
+		t.Errorf("Want: %v, Got: %v %v", "http://proxy.example.com:3128", got, err)
+	}
+
+	_, err = newTransport("", "proxy.example.com")
+	if err == nil {
+		t.Errorf("Input: %v, Want: error, Got: %v", "proxy.example.com", err)
+	}
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+const diffContext = 3
+
+type diffOp struct {
+	kind byte // ' ', '-' or '+'
+	line string
+}
+
+// unifiedDiff returns a unified diff between two versions of a file, empty if both are equal
+func unifiedDiff(fileName, before, after string) string {
+	if before == after {
+		return ""
+	}
+	ops := diffLines(splitLines(before), splitLines(after))
+
+	var output strings.Builder
+	fmt.Fprintf(&output, "--- a/%s\n+++ b/%s\n", fileName, fileName)
+
+	for start := 0; start < len(ops); {
+		if ops[start].kind == ' ' {
+			start++
+			continue
+		}
+
+		// widen the hunk with context lines and merge changes closer than twice the context
+		hunkStart := start - diffContext
+		if hunkStart < 0 {
+			hunkStart = 0
+		}
+		hunkEnd := start
+		unchanged := 0
+		for i := start; i < len(ops) && unchanged <= 2*diffContext; i++ {
+			if ops[i].kind == ' ' {
+				unchanged++
+				continue
+			}
+			unchanged = 0
+			hunkEnd = i + 1
+		}
+		contextEnd := hunkEnd + diffContext
+		if contextEnd > len(ops) {
+			contextEnd = len(ops)
+		}
+
+		beforeLine, afterLine := 1, 1
+		for _, op := range ops[:hunkStart] {
+			if op.kind != '+' {
+				beforeLine++
+			}
+			if op.kind != '-' {
+				afterLine++
+			}
+		}
+		beforeCount, afterCount := 0, 0
+		for _, op := range ops[hunkStart:contextEnd] {
+			if op.kind != '+' {
+				beforeCount++
+			}
+			if op.kind != '-' {
+				afterCount++
+			}
+		}
+		fmt.Fprintf(&output, "@@ -%s +%s @@\n", hunkRange(beforeLine, beforeCount), hunkRange(afterLine, afterCount))
+		for _, op := range ops[hunkStart:contextEnd] {
+			fmt.Fprintf(&output, "%c%s\n", op.kind, op.line)
+		}
+
+		start = contextEnd
+	}
+
+	return output.String()
+}
+
+func hunkRange(line, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", line-1)
+	}
+	if count == 1 {
+		return fmt.Sprintf("%d", line)
+	}
+	return fmt.Sprintf("%d,%d", line, count)
+}
+
+func splitLines(content string) []string {
+	if len(content) == 0 {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(content, "\n"), "\n")
+}
+
+// diffLines computes the edit script between a and b using their longest common subsequence
+func diffLines(a, b []string) []diffOp {
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var ops []diffOp
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			ops = append(ops, diffOp{' ', a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ops = append(ops, diffOp{'-', a[i]})
+			i++
+		default:
+			ops = append(ops, diffOp{'+', b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		ops = append(ops, diffOp{'-', a[i]})
+	}
+	for ; j < len(b); j++ {
+		ops = append(ops, diffOp{'+', b[j]})
+	}
+	return ops
+}
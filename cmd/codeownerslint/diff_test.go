@@ -0,0 +1,52 @@
+package main
+
+import "testing"
+
+func TestUnifiedDiff(t *testing.T) {
+	testCases := []struct {
+		before string
+		after  string
+		want   string
+	}{
+		{
+			before: "a\nb\n",
+			after:  "a\nb\n",
+			want:   "",
+		},
+		{
+			before: "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n",
+			after:  "1\nX\n3\n4\n5\n6\n7\n8\n9\n10\n11\n",
+			want: `--- a/CODEOWNERS
++++ b/CODEOWNERS
+@@ -1,5 +1,5 @@
+ 1
+-2
++X
+ 3
+ 4
+ 5
+@@ -9,4 +9,3 @@
+ 9
+ 10
+ 11
+-12
+`,
+		},
+		{
+			before: "",
+			after:  "a\n",
+			want: `--- a/CODEOWNERS
++++ b/CODEOWNERS
+@@ -0,0 +1 @@
++a
+`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		got := unifiedDiff("CODEOWNERS", testCase.before, testCase.after)
+		if got != testCase.want {
+			t.Errorf("Input: %q %q, Want: '%s', Got: '%s'", testCase.before, testCase.after, testCase.want, got)
+		}
+	}
+}
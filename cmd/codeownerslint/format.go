@@ -0,0 +1,79 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/fmenezes/codeowners"
+)
+
+type formatOptions struct {
+	directory string
+	check     bool
+	platform  string
+}
+
+func runFormat(wr io.Writer, opt formatOptions) exitCode {
+	dir, err := filepath.Abs(opt.directory)
+	if err != nil {
+		fmt.Fprintf(wr, "Unexpected error when parsing directory: %v", err)
+		return unexpectedErrorCode
+	}
+
+	profile, err := codeowners.PlatformProfile(opt.platform)
+	if err != nil {
+		fmt.Fprintf(wr, "Unexpected error when selecting platform: %v", err)
+		return unexpectedErrorCode
+	}
+
+	fileLocation, err := profile.FindCodeownersFile(dir)
+	if err != nil {
+		fmt.Fprintf(wr, "Unexpected error when finding CODEOWNERS file: %v", err)
+		return unexpectedErrorCode
+	}
+	filePath := filepath.Join(dir, fileLocation)
+
+	content, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		fmt.Fprintf(wr, "Unexpected error when reading CODEOWNERS file: %v", err)
+		return unexpectedErrorCode
+	}
+
+	file, err := codeowners.ParseDialect(bytes.NewReader(content), profile.Dialect)
+	if err != nil {
+		fmt.Fprintf(wr, "Unexpected error when parsing CODEOWNERS file: %v", err)
+		return unexpectedErrorCode
+	}
+
+	var formatted bytes.Buffer
+	err = codeowners.NewEncoder(&formatted).Encode(codeowners.Format(file))
+	if err != nil {
+		fmt.Fprintf(wr, "Unexpected error when formatting CODEOWNERS file: %v", err)
+		return unexpectedErrorCode
+	}
+
+	if bytes.Equal(content, formatted.Bytes()) {
+		return successCode
+	}
+
+	if opt.check {
+		fmt.Fprint(wr, unifiedDiff(filepath.ToSlash(fileLocation), string(content), formatted.String()))
+		return errorCode
+	}
+
+	info, err := os.Stat(filePath)
+	if err != nil {
+		fmt.Fprintf(wr, "Unexpected error when writing CODEOWNERS file: %v", err)
+		return unexpectedErrorCode
+	}
+	err = ioutil.WriteFile(filePath, formatted.Bytes(), info.Mode())
+	if err != nil {
+		fmt.Fprintf(wr, "Unexpected error when writing CODEOWNERS file: %v", err)
+		return unexpectedErrorCode
+	}
+	return successCode
+}
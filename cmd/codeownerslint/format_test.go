@@ -0,0 +1,162 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func testRunFormat(opt formatOptions) (string, exitCode) {
+	var output bytes.Buffer
+	exitCode := runFormat(&output, opt)
+	return output.String(), exitCode
+}
+
+func TestFormatCheckPass(t *testing.T) {
+	got, gotCode := testRunFormat(formatOptions{
+		directory: "../../test/data/pass",
+		check:     true,
+	})
+	if gotCode != successCode || got != "" {
+		t.Errorf("Want: %d '', Got: %d '%s'", successCode, gotCode, got)
+	}
+}
+
+func TestFormatCheckEscapedHash(t *testing.T) {
+	got, gotCode := testRunFormat(formatOptions{
+		directory: "../../test/data/escaped_hash",
+		check:     true,
+	})
+	if gotCode != successCode || got != "" {
+		t.Errorf("Want: %d '', Got: %d '%s'", successCode, gotCode, got)
+	}
+}
+
+func TestFormatCheckUnformatted(t *testing.T) {
+	want := `--- a/CODEOWNERS
++++ b/CODEOWNERS
+@@ -1,9 +1,6 @@
+ # Owners of the project
+ 
+-
+-*   @default   # everyone
+-docs/	@writers   @editors
+-  # nested docs
++*                   @default # everyone
++docs/               @writers @editors
++# nested docs
+ /src/very/long/path @gophers
+-
+-
+`
+	got, gotCode := testRunFormat(formatOptions{
+		directory: "../../test/data/unformatted",
+		check:     true,
+	})
+	if gotCode != errorCode || got != want {
+		t.Errorf("Want: %d '%s', Got: %d '%s'", errorCode, want, gotCode, got)
+	}
+}
+
+func TestFormatWrite(t *testing.T) {
+	dir, err := ioutil.TempDir("", "codeownerslint")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	content, err := ioutil.ReadFile("../../test/data/unformatted/CODEOWNERS")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ioutil.WriteFile(filepath.Join(dir, "CODEOWNERS"), content, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, gotCode := testRunFormat(formatOptions{directory: dir})
+	if gotCode != successCode {
+		t.Errorf("Want: %d, Got: %d", successCode, gotCode)
+	}
+
+	got, gotCode := testRunFormat(formatOptions{directory: dir, check: true})
+	if gotCode != successCode || got != "" {
+		t.Errorf("Want: %d '', Got: %d '%s'", successCode, gotCode, got)
+	}
+}
+
+func TestFormatWriteKeepsMode(t *testing.T) {
+	dir, err := ioutil.TempDir("", "codeownerslint")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	content, err := ioutil.ReadFile("../../test/data/unformatted/CODEOWNERS")
+	if err != nil {
+		t.Fatal(err)
+	}
+	filePath := filepath.Join(dir, "CODEOWNERS")
+	err = ioutil.WriteFile(filePath, content, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.Chmod(filePath, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, gotCode := testRunFormat(formatOptions{directory: dir})
+	info, err := os.Stat(filePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gotCode != successCode || info.Mode().Perm() != 0600 {
+		t.Errorf("Want: %d %v, Got: %d %v", successCode, os.FileMode(0600), gotCode, info.Mode().Perm())
+	}
+}
+
+func TestFormatNoCodeowners(t *testing.T) {
+	assertFormatCode(t, formatOptions{directory: "../../test/data"}, unexpectedErrorCode)
+}
+
+func assertFormatCode(t *testing.T, opt formatOptions, want exitCode) {
+	_, got := testRunFormat(opt)
+	if got != want {
+		t.Errorf("Input: %v Want: %d Got: %d", opt, want, got)
+	}
+}
+
+func TestFormatGitlabSections(t *testing.T) {
+	got, gotCode := testRunFormat(formatOptions{
+		directory: "../../test/data/gitlab_sections",
+		check:     true,
+		platform:  "gitlab",
+	})
+	if gotCode != successCode || got != "" {
+		t.Errorf("Want: %d '', Got: %d '%s'", successCode, gotCode, got)
+	}
+}
+
+func TestFormatPlatformLocations(t *testing.T) {
+	testCases := []struct {
+		platform string
+		want     exitCode
+	}{
+		{platform: "bitbucket", want: successCode},
+		{platform: "github", want: unexpectedErrorCode},
+		{platform: "svn", want: unexpectedErrorCode},
+	}
+	for _, testCase := range testCases {
+		_, gotCode := testRunFormat(formatOptions{
+			directory: "../../test/data/bitbucket",
+			check:     true,
+			platform:  testCase.platform,
+		})
+		if gotCode != testCase.want {
+			t.Errorf("Input: %v, Want: %d, Got: %d", testCase.platform, testCase.want, gotCode)
+		}
+	}
+}
@@ -1,14 +1,21 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
+	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"text/tabwriter"
 	"text/template"
+	"time"
 
 	"github.com/fmenezes/codeowners"
-	_ "github.com/fmenezes/codeowners/checkers"
+	"github.com/fmenezes/codeowners/checkers"
 )
 
 type options struct {
@@ -16,6 +23,39 @@ type options struct {
 	format    string
 	token     string
 	tokenType string
+	fix       bool
+	diff      bool
+	config    string
+	enable    []string
+	disable   []string
+	timeout   time.Duration
+	verbose   bool
+
+	githubURL       string
+	githubUploadURL string
+	caFile          string
+	proxy           string
+	repo            string
+	remote          string
+
+	platform    string
+	gitlabURL   string
+	gitlabToken string
+	giteaURL    string
+	giteaToken  string
+
+	githubAppID             int64
+	githubAppKey            string
+	githubAppInstallationID int64
+
+	accessFailures    string
+	accessConcurrency int
+	accessSnapshot    string
+	cacheDir          string
+	cacheTTL          time.Duration
+	noCache           bool
+
+	tokenErr error // tokenErr is the error discovering the token, it is only reported when Access runs
 }
 
 type exitCode int
@@ -34,9 +74,17 @@ func run(wr io.Writer, opt options) exitCode {
 		return unexpectedErrorCode
 	}
 
+	config, err := loadConfig(dir, opt)
+	if err != nil {
+		fmt.Fprintf(wr, "Unexpected error when loading configuration: %v", err)
+		return unexpectedErrorCode
+	}
+
 	format := "{{ .Position.Format }} ::{{ .Severity.Name }}:: {{ .Message }} [{{ .CheckName }}]"
 	if len(opt.format) > 0 {
 		format = opt.format
+	} else if len(config.Format) > 0 {
+		format = config.Format
 	}
 	format = fmt.Sprintf("%s\n", format)
 	tpl, err := template.New("main").Funcs(template.FuncMap{
@@ -48,22 +96,305 @@ func run(wr io.Writer, opt options) exitCode {
 		return unexpectedErrorCode
 	}
 
-	checkers := codeowners.AvailableCheckers()
+	checkOptions, err := buildCheckOptions(dir, opt, config)
+	if err != nil {
+		fmt.Fprintf(wr, "Unexpected error when selecting checkers: %v", err)
+		return unexpectedErrorCode
+	}
+	if opt.tokenErr != nil && accessEnabled(checkOptions) {
+		fmt.Fprintf(wr, "Unexpected error when discovering token: %v", opt.tokenErr)
+		return unexpectedErrorCode
+	}
+
+	ctx := context.Background()
+	if opt.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opt.timeout)
+		defer cancel()
+	}
 
-	checks, _ := codeowners.Check(codeowners.CheckOptions{
+	checks, err := codeowners.CheckContext(ctx, checkOptions)
+	if err != nil {
+		fmt.Fprintf(wr, "Unexpected error when checking: %v", err)
+		return unexpectedErrorCode
+	}
+
+	code := resultsCode(checks)
+	if opt.fix || opt.diff {
+		checks, err = applyFixes(wr, dir, checks, opt.fix)
+		if err != nil {
+			fmt.Fprintf(wr, "Unexpected error when applying fixes: %v", err)
+			return unexpectedErrorCode
+		}
+		if opt.fix {
+			code = resultsCode(checks)
+		}
+	}
+
+	err = writeResults(wr, tpl, checks)
+	if err != nil {
+		fmt.Fprintf(wr, "Unexpected error when writing results: %v", err)
+		return unexpectedErrorCode
+	}
+
+	return code
+}
+
+func loadConfig(dir string, opt options) (*codeowners.Config, error) {
+	configPath := opt.config
+	if len(configPath) == 0 {
+		profile, err := codeowners.PlatformProfile(opt.platform)
+		if err != nil {
+			profile = codeowners.GithubProfile // unknown platforms are reported when building the check options
+		}
+		configPath = profile.FindConfig(dir)
+	}
+	if len(configPath) == 0 {
+		return &codeowners.Config{}, nil
+	}
+	return codeowners.LoadConfig(configPath)
+}
+
+// buildCheckOptions merges the configuration into the command line options, -enable and -disable take precedence over the configuration
+func buildCheckOptions(dir string, opt options, config *codeowners.Config) (codeowners.CheckOptions, error) {
+	checkOptions := codeowners.CheckOptions{
 		Directory:       dir,
-		Checkers:        checkers,
+		Checkers:        codeowners.AvailableCheckers(),
 		GithubToken:     opt.token,
 		GithubTokenType: opt.tokenType,
-	})
+		GithubURL:       opt.githubURL,
+		GithubUploadURL: opt.githubUploadURL,
+		CAFile:          opt.caFile,
+		Proxy:           opt.proxy,
+		Repository:      opt.repo,
+		Remote:          opt.remote,
+		Platform:        opt.platform,
+		GitlabURL:       opt.gitlabURL,
+		GitlabToken:     opt.gitlabToken,
+		GiteaURL:        opt.giteaURL,
+		GiteaToken:      opt.giteaToken,
 
-	code := successCode
+		GithubAppID:             opt.githubAppID,
+		GithubAppPrivateKeyFile: opt.githubAppKey,
+		GithubAppInstallationID: opt.githubAppInstallationID,
+	}
+	switch opt.platform {
+	case "", codeowners.GithubPlatform, codeowners.GitlabPlatform, codeowners.BitbucketPlatform:
+	case codeowners.GiteaPlatform:
+		if len(opt.giteaURL) == 0 {
+			return checkOptions, errors.New("Missing Gitea URL, see -gitea-url")
+		}
+	default:
+		return checkOptions, fmt.Errorf("Unknown platform '%s', expected github, gitlab, gitea or bitbucket", opt.platform)
+	}
+	if opt.githubAppID != 0 && len(opt.githubAppKey) == 0 {
+		return checkOptions, fmt.Errorf("Missing private key of Github App %d, see -github-app-key", opt.githubAppID)
+	}
+	if len(opt.accessFailures) > 0 {
+		if err := (checkers.Access{}).ValidateOptions(map[string]string{checkers.AccessFailuresOption: opt.accessFailures}); err != nil {
+			return checkOptions, err
+		}
+		setCheckerOption(&checkOptions, "Access", checkers.AccessFailuresOption, opt.accessFailures)
+	}
+	if opt.accessConcurrency < 0 {
+		return checkOptions, fmt.Errorf("Invalid access concurrency %d", opt.accessConcurrency)
+	}
+	if opt.accessConcurrency > 0 {
+		setCheckerOption(&checkOptions, "Access", checkers.AccessConcurrencyOption, strconv.Itoa(opt.accessConcurrency))
+	}
+	if len(opt.accessSnapshot) > 0 {
+		setCheckerOption(&checkOptions, "Access", checkers.AccessSnapshotOption, opt.accessSnapshot)
+	}
+	if opt.noCache {
+		setCheckerOption(&checkOptions, "Access", checkers.AccessCacheDirOption, "")
+	} else if len(opt.cacheDir) > 0 {
+		setCheckerOption(&checkOptions, "Access", checkers.AccessCacheDirOption, opt.cacheDir)
+	}
+	if opt.cacheTTL < 0 {
+		return checkOptions, fmt.Errorf("Invalid cache TTL %v", opt.cacheTTL)
+	}
+	if opt.cacheTTL > 0 {
+		setCheckerOption(&checkOptions, "Access", checkers.AccessCacheTTLOption, opt.cacheTTL.String())
+	}
+	config.ApplyTo(&checkOptions)
+
+	registered := make(map[string]bool)
+	for _, checker := range codeowners.AvailableCheckers() {
+		registered[checker] = true
+	}
+	selected := make(map[string]bool)
+	for _, checker := range checkOptions.Checkers {
+		selected[checker] = true
+	}
+	for _, checker := range opt.enable {
+		if !registered[checker] {
+			return checkOptions, fmt.Errorf("Unknown checker '%s'", checker)
+		}
+		selected[checker] = true
+	}
+	for _, checker := range opt.disable {
+		if !registered[checker] {
+			return checkOptions, fmt.Errorf("Unknown checker '%s'", checker)
+		}
+		selected[checker] = false
+	}
+
+	checkOptions.Checkers = []string{}
+	for _, checker := range codeowners.AvailableCheckers() {
+		if selected[checker] {
+			checkOptions.Checkers = append(checkOptions.Checkers, checker)
+		}
+	}
+	return checkOptions, nil
+}
+
+func setCheckerOption(checkOptions *codeowners.CheckOptions, checker, key, value string) {
+	if checkOptions.CheckerOptions == nil {
+		checkOptions.CheckerOptions = make(map[string]map[string]string)
+	}
+	if checkOptions.CheckerOptions[checker] == nil {
+		checkOptions.CheckerOptions[checker] = make(map[string]string)
+	}
+	checkOptions.CheckerOptions[checker][key] = value
+}
+
+// hasCredentials tells whether opt has the credentials of its platform, a token or a Github App for Github and a token for the others except Bitbucket, where owners are not looked up
+func hasCredentials(opt options) bool {
+	switch opt.platform {
+	case codeowners.GitlabPlatform:
+		return len(opt.gitlabToken) > 0
+	case codeowners.GiteaPlatform:
+		return len(opt.giteaToken) > 0
+	case codeowners.BitbucketPlatform:
+		return false
+	}
+	return len(opt.token) > 0 || opt.githubAppID != 0
+}
+
+func runListCheckers(wr io.Writer, opt options) exitCode {
+	dir, err := filepath.Abs(opt.directory)
+	if err != nil {
+		fmt.Fprintf(wr, "Unexpected error when parsing directory: %v", err)
+		return unexpectedErrorCode
+	}
+
+	config, err := loadConfig(dir, opt)
+	if err != nil {
+		fmt.Fprintf(wr, "Unexpected error when loading configuration: %v", err)
+		return unexpectedErrorCode
+	}
+
+	checkOptions, err := buildCheckOptions(dir, opt, config)
+	if err != nil {
+		fmt.Fprintf(wr, "Unexpected error when selecting checkers: %v", err)
+		return unexpectedErrorCode
+	}
+	selected := make(map[string]bool)
+	for _, checker := range checkOptions.Checkers {
+		selected[checker] = true
+	}
+
+	w := tabwriter.NewWriter(wr, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tSEVERITY\tSTATUS\tDESCRIPTION")
+	for _, info := range codeowners.DescribeCheckers() {
+		severity := info.Severity
+		if override, found := checkOptions.Severities[info.Name]; found {
+			severity = override
+		}
+		status := "enabled"
+		if !selected[info.Name] {
+			status = "disabled"
+		} else if info.RequiresToken && !hasCredentials(opt) &&
+			len(checkOptions.CheckerOptions[info.Name][checkers.AccessSnapshotOption]) == 0 {
+			switch opt.platform {
+			case codeowners.GitlabPlatform:
+				status = "skipped (no token, see -gitlab-token)"
+			case codeowners.GiteaPlatform:
+				status = "skipped (no token, see -gitea-token)"
+			case codeowners.BitbucketPlatform:
+				status = "skipped (not supported on bitbucket)"
+			default:
+				status = "skipped (no token, see -t)"
+			}
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", info.Name, severity.Name(), status, info.Description)
+	}
+	w.Flush()
+
+	return successCode
+}
+
+// accessEnabled tells whether the Access checker is selected
+func accessEnabled(checkOptions codeowners.CheckOptions) bool {
+	for _, checker := range checkOptions.Checkers {
+		if checker == "Access" {
+			return true
+		}
+	}
+	return false
+}
+
+// applyFixes applies the suggested fixes to the CODEOWNERS file, writing it when write is true or printing a diff otherwise.
+// It returns the results that were not fixed.
+func applyFixes(wr io.Writer, dir string, checks []codeowners.CheckResult, write bool) ([]codeowners.CheckResult, error) {
+	byFile := make(map[string][]codeowners.CheckResult)
+	files := []string{}
+	remaining := []codeowners.CheckResult{}
 	for _, check := range checks {
-		err = tpl.Execute(wr, check)
+		if check.SuggestedFix == nil {
+			remaining = append(remaining, check)
+			continue
+		}
+		filePath := check.Position.FilePath
+		if _, found := byFile[filePath]; !found {
+			files = append(files, filePath)
+		}
+		byFile[filePath] = append(byFile[filePath], check)
+	}
+
+	for _, filePath := range files {
+		fullPath := filepath.Join(dir, filePath)
+		content, err := ioutil.ReadFile(fullPath)
 		if err != nil {
-			fmt.Fprintf(wr, "Unexpected error when writing results: %v", err)
-			return unexpectedErrorCode
+			return nil, err
+		}
+		fixed, conflicts, err := codeowners.ApplyFixes(content, byFile[filePath])
+		if err != nil {
+			return nil, err
+		}
+		remaining = append(remaining, conflicts...)
+
+		if !write {
+			fmt.Fprint(wr, unifiedDiff(filepath.ToSlash(filePath), string(content), string(fixed)))
+			continue
+		}
+		info, err := os.Stat(fullPath)
+		if err != nil {
+			return nil, err
+		}
+		err = ioutil.WriteFile(fullPath, fixed, info.Mode())
+		if err != nil {
+			return nil, err
 		}
+	}
+
+	codeowners.SortResults(remaining)
+	return remaining, nil
+}
+
+func writeResults(wr io.Writer, tpl *template.Template, checks []codeowners.CheckResult) error {
+	for _, check := range checks {
+		err := tpl.Execute(wr, check)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func resultsCode(checks []codeowners.CheckResult) exitCode {
+	code := successCode
+	for _, check := range checks {
 		switch check.Severity {
 		case codeowners.Error:
 			if code < errorCode {
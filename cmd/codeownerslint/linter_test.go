@@ -2,7 +2,15 @@ package main
 
 import (
 	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/fmenezes/codeowners"
 )
 
 func testRun(opt options) (string, exitCode) {
@@ -84,6 +92,369 @@ func TestMultipleCodeOwners(t *testing.T) {
 	assert(t, options{
 		directory: "../../test/data/multiple_codeowners",
 		format:    "",
-	}, warningCode, `CODEOWNERS 0 ::Warning:: Multiple CODEOWNERS files found (CODEOWNERS, docs/CODEOWNERS) [MultipleCodeowners]
+	}, warningCode, `docs/CODEOWNERS 0 ::Warning:: CODEOWNERS file is shadowed by CODEOWNERS, which github reads first [MultipleCodeowners]
+`)
+}
+
+func TestDiff(t *testing.T) {
+	assert(t, options{
+		directory: "../../test/data/fixable",
+		diff:      true,
+	}, errorCode, `--- a/CODEOWNERS
++++ b/CODEOWNERS
+@@ -1,3 +1,3 @@
+ *         @default
+-docs/     owner @writers @writers
+-file1.txt @a 
++docs/     @owner @writers
++file1.txt @a
+`)
+}
+
+func TestDiffEscapedHash(t *testing.T) {
+	assert(t, options{
+		directory: "../../test/data/escaped_hash",
+		diff:      true,
+	}, successCode, "")
+}
+
+func TestApplyFixesSortsConflicts(t *testing.T) {
+	dir, err := ioutil.TempDir("", "codeownerslint")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	err = ioutil.WriteFile(filepath.Join(dir, "CODEOWNERS"), []byte("a @x\nb @y\n"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	replace := func(endColumn int, newText string) *codeowners.SuggestedFix {
+		position := codeowners.Position{FilePath: "CODEOWNERS", StartLine: 1, StartColumn: 1, EndLine: 1, EndColumn: endColumn}
+		return &codeowners.SuggestedFix{Edits: []codeowners.TextEdit{codeowners.ReplaceSpan(position, newText)}}
+	}
+	checks := []codeowners.CheckResult{
+		{Position: codeowners.Position{FilePath: "CODEOWNERS", StartLine: 1}, CheckName: "A", SuggestedFix: replace(2, "c")},
+		{Position: codeowners.Position{FilePath: "CODEOWNERS", StartLine: 1}, CheckName: "B", SuggestedFix: replace(3, "d ")},
+		{Position: codeowners.Position{FilePath: "CODEOWNERS", StartLine: 2}, CheckName: "C"},
+	}
+	got, err := applyFixes(ioutil.Discard, dir, checks, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"B", "C"}
+	var names []string
+	for _, check := range got {
+		names = append(names, check.CheckName)
+	}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("Want: %v, Got: %v", want, names)
+	}
+}
+
+func TestFix(t *testing.T) {
+	dir, err := ioutil.TempDir("", "codeownerslint")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	content, err := ioutil.ReadFile("../../test/data/fixable/CODEOWNERS")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ioutil.WriteFile(filepath.Join(dir, "CODEOWNERS"), content, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert(t, options{
+		directory: dir,
+		fix:       true,
+	}, successCode, "")
+
+	got, err := ioutil.ReadFile(filepath.Join(dir, "CODEOWNERS"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "*         @default\ndocs/     @owner @writers\nfile1.txt @a\n"
+	if string(got) != want {
+		t.Errorf("Want: %q, Got: %q", want, string(got))
+	}
+
+	assert(t, options{
+		directory: dir,
+		fix:       true,
+	}, successCode, "")
+}
+
+func TestConfig(t *testing.T) {
+	assert(t, options{
+		directory: "../../test/data/config",
+	}, warningCode, `.github/CODEOWNERS 2:11-18 Warning [InvalidOwner]
+`)
+}
+
+func TestConfigFormatOverride(t *testing.T) {
+	assert(t, options{
+		directory: "../../test/data/config",
+		format:    "{{ .CheckName }}",
+	}, warningCode, `InvalidOwner
+`)
+}
+
+func TestConfigExplicitPath(t *testing.T) {
+	assert(t, options{
+		directory: "../../test/data/no_owners",
+		config:    "../../test/data/config/.github/.codeownerslint.yaml",
+	}, successCode, "")
+}
+
+func TestConfigInvalid(t *testing.T) {
+	assertCode(t, options{
+		directory: "../../test/data/no_owners",
+		config:    "../../test/data/invalid_config.yaml",
+	}, unexpectedErrorCode)
+}
+
+func TestConfigInvalidAccessFailures(t *testing.T) {
+	assertCode(t, options{
+		directory: "../../test/data/no_owners",
+		config:    "../../test/data/invalid_options_config.yaml",
+	}, unexpectedErrorCode)
+}
+
+func TestDisable(t *testing.T) {
+	assert(t, options{
+		directory: "../../test/data/no_owners",
+		disable:   []string{"NoOwner"},
+	}, successCode, "")
+}
+
+func TestEnableOverridesConfig(t *testing.T) {
+	assert(t, options{
+		directory: "../../test/data/config",
+		enable:    []string{"NoOwner"},
+		format:    "{{ .Position.Format }} [{{ .CheckName }}]",
+	}, errorCode, `.github/CODEOWNERS 1 [NoOwner]
+.github/CODEOWNERS 2:11-18 [InvalidOwner]
+`)
+}
+
+func TestUnknownChecker(t *testing.T) {
+	assertCode(t, options{
+		directory: "../../test/data/pass",
+		enable:    []string{"Unknown"},
+	}, unexpectedErrorCode)
+	assertCode(t, options{
+		directory: "../../test/data/pass",
+		disable:   []string{"Unknown"},
+	}, unexpectedErrorCode)
+}
+
+func TestListCheckers(t *testing.T) {
+	want := `NAME              SEVERITY  STATUS                      DESCRIPTION
+Access            Error     skipped (no token, see -t)  Owners must have write access to the repository
+DuplicateOwner    Warning   enabled                     Owners must not be listed more than once in the same line
+DuplicatePattern  Warning   enabled                     Patterns must not be declared more than once
+Formatting        Warning   disabled                    Lines must follow the canonical format
+InvalidOwner      Warning   enabled                     Owners must be valid users, teams or emails
+InvalidPattern    Error     enabled                     Patterns must compile in the dialect of the platform
+InvalidSection    Error     enabled                     Section headers must be well formed
+NoOwner           Error     disabled                    Rules must specify at least one owner
+`
+	var output bytes.Buffer
+	gotCode := runListCheckers(&output, options{
+		directory: "../../test/data/config",
+		disable:   []string{"Formatting"},
+	})
+	if gotCode != successCode || output.String() != want {
+		t.Errorf("Want: %d '%s', Got: %d '%s'", successCode, want, gotCode, output.String())
+	}
+}
+
+func TestTimeout(t *testing.T) {
+	assertCode(t, options{
+		directory: "../../test/data/pass",
+		timeout:   time.Nanosecond,
+	}, unexpectedErrorCode)
+}
+
+func TestInvalidAccessFailures(t *testing.T) {
+	assertCode(t, options{
+		directory:      "../../test/data/pass",
+		accessFailures: "sometimes",
+	}, unexpectedErrorCode)
+}
+
+func TestAccessFailuresOption(t *testing.T) {
+	checkOptions, err := buildCheckOptions(".", options{accessFailures: "skip"}, &codeowners.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := checkOptions.CheckerOptions["Access"]["failures"]; got != "skip" {
+		t.Errorf("Want: skip, Got: %s", got)
+	}
+}
+
+func TestInvalidAccessConcurrency(t *testing.T) {
+	assertCode(t, options{
+		directory:         "../../test/data/pass",
+		accessConcurrency: -1,
+	}, unexpectedErrorCode)
+}
+
+func TestAccessConcurrencyOption(t *testing.T) {
+	checkOptions, err := buildCheckOptions(".", options{accessConcurrency: 4}, &codeowners.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := checkOptions.CheckerOptions["Access"]["concurrency"]; got != "4" {
+		t.Errorf("Want: 4, Got: %s", got)
+	}
+}
+
+func TestGithubOptions(t *testing.T) {
+	input := options{
+		githubURL:       "https://github.example.com/api/v3/",
+		githubUploadURL: "https://github.example.com/api/uploads/",
+		caFile:          "ca.pem",
+		proxy:           "http://proxy.example.com:3128",
+		repo:            "owner/repo",
+		remote:          "upstream",
+	}
+	checkOptions, err := buildCheckOptions(".", input, &codeowners.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := []string{checkOptions.GithubURL, checkOptions.GithubUploadURL, checkOptions.CAFile, checkOptions.Proxy,
+		checkOptions.Repository, checkOptions.Remote}
+	want := []string{input.githubURL, input.githubUploadURL, input.caFile, input.proxy, input.repo, input.remote}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Input: %v, Want: %v, Got: %v", input, want, got)
+	}
+}
+
+func TestGithubAppMissingKey(t *testing.T) {
+	assertCode(t, options{
+		directory:   "../../test/data/pass",
+		githubAppID: 1234,
+	}, unexpectedErrorCode)
+}
+
+func TestListCheckersGithubApp(t *testing.T) {
+	var output bytes.Buffer
+	runListCheckers(&output, options{
+		directory:    "../../test/data/config",
+		githubAppID:  1234,
+		githubAppKey: "app.pem",
+	})
+	if got := strings.Split(output.String(), "\n")[1]; !strings.HasPrefix(got, "Access            Error     enabled") {
+		t.Errorf("Want: Access enabled, Got: '%s'", got)
+	}
+}
+
+func TestAccessCacheOptions(t *testing.T) {
+	config := &codeowners.Config{Checkers: map[string]codeowners.CheckerConfig{
+		"Access": {Options: map[string]string{"cache-dir": ".cache/configured"}},
+	}}
+	tests := []struct {
+		opt     options
+		wantDir string
+		wantTTL string
+	}{
+		{opt: options{}, wantDir: ".cache/configured", wantTTL: ""},
+		{opt: options{cacheDir: ".cache/flag", cacheTTL: 30 * time.Minute}, wantDir: ".cache/flag", wantTTL: "30m0s"},
+		{opt: options{cacheDir: ".cache/flag", noCache: true}, wantDir: "", wantTTL: ""},
+	}
+	for _, test := range tests {
+		checkOptions, err := buildCheckOptions(".", test.opt, config)
+		if err != nil {
+			t.Fatal(err)
+		}
+		gotDir := checkOptions.CheckerOptions["Access"]["cache-dir"]
+		gotTTL := checkOptions.CheckerOptions["Access"]["cache-ttl"]
+		if gotDir != test.wantDir || gotTTL != test.wantTTL {
+			t.Errorf("Input: %v, Want: %v %v, Got: %v %v", test.opt, test.wantDir, test.wantTTL, gotDir, gotTTL)
+		}
+	}
+}
+
+func TestPlatformOptions(t *testing.T) {
+	input := options{platform: "gitlab", gitlabURL: "https://gitlab.example.com/api/v4", gitlabToken: "token",
+		giteaURL: "https://gitea.example.com/api/v1", giteaToken: "gitea-token"}
+	checkOptions, err := buildCheckOptions(".", input, &codeowners.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := []string{checkOptions.Platform, checkOptions.GitlabURL, checkOptions.GitlabToken, checkOptions.GiteaURL, checkOptions.GiteaToken}
+	want := []string{input.platform, input.gitlabURL, input.gitlabToken, input.giteaURL, input.giteaToken}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Input: %v, Want: %v, Got: %v", input, want, got)
+	}
+}
+
+func TestUnknownPlatform(t *testing.T) {
+	assertCode(t, options{
+		directory: "../../test/data/pass",
+		platform:  "svn",
+	}, unexpectedErrorCode)
+}
+
+func TestGiteaMissingURL(t *testing.T) {
+	assertCode(t, options{
+		directory: "../../test/data/pass",
+		platform:  "gitea",
+	}, unexpectedErrorCode)
+}
+
+func TestGiteaPatterns(t *testing.T) {
+	assert(t, options{
+		directory: "../../test/data/gitea",
+		platform:  "gitea",
+		giteaURL:  "https://gitea.example.com/api/v1",
+	}, errorCode, `CODEOWNERS 2:1-11 ::Error:: Pattern 'docs/[z-a]' is invalid in the Gitea dialect: error parsing regexp: invalid character class range: `+"`z-a`"+` [InvalidPattern]
+CODEOWNERS 4:1-7 ::Warning:: Pattern '.*\.go' is already declared on line 3, the owners of both are combined [DuplicatePattern]
+`)
+}
+
+func TestListCheckersGitlab(t *testing.T) {
+	var output bytes.Buffer
+	runListCheckers(&output, options{
+		directory: "../../test/data/config",
+		platform:  "gitlab",
+		token:     "github-token",
+	})
+	if got := strings.Split(output.String(), "\n")[1]; !strings.Contains(got, "skipped (no token, see -gitlab-token)") {
+		t.Errorf("Want: Access skipped, Got: '%s'", got)
+	}
+}
+
+func TestListCheckersBitbucket(t *testing.T) {
+	var output bytes.Buffer
+	runListCheckers(&output, options{
+		directory: "../../test/data/config",
+		platform:  "bitbucket",
+	})
+	if got := strings.Split(output.String(), "\n")[1]; !strings.Contains(got, "skipped (not supported on bitbucket)") {
+		t.Errorf("Want: Access skipped, Got: '%s'", got)
+	}
+}
+
+func TestGitlabSections(t *testing.T) {
+	assert(t, options{
+		directory: "../../test/data/gitlab_sections",
+		platform:  "gitlab",
+	}, errorCode, `CODEOWNERS 10:1-8 ::Error:: Section header '[Broken' is invalid: missing closing bracket [InvalidSection]
+CODEOWNERS 11:1-14 ::Error:: Section header '[Frontend][0]' is invalid: invalid approval count '0' [InvalidSection]
+`)
+}
+
+func TestBitbucketOwners(t *testing.T) {
+	assert(t, options{
+		directory: "../../test/data/bitbucket",
+		platform:  "bitbucket",
+	}, errorCode, `.bitbucket/CODEOWNERS 6:7-27 ::Error:: Owner '@@Backend:fastest(1)' is invalid: unknown selection strategy 'fastest', expected random or least_busy [InvalidOwner]
+.bitbucket/CODEOWNERS 7:7-22 ::Error:: Owner '@dave:random(1)' is invalid: selection strategies only apply to @@groups [InvalidOwner]
 `)
 }
@@ -6,20 +6,59 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"strings"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "fmt" {
+		formatMain(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "snapshot" {
+		snapshotMain(os.Args[2:])
+		return
+	}
+
+	opt := options{}
 	dir := flag.String("d", ".", "Directory: specifies the directory you want to use to lint the CODEOWNERS file")
 	format := flag.String("f", "", "Format: specifies the format you want to return lint results")
-	token := flag.String("t", "", "Token: specifies the Github's token you want to use")
-	tokenType := flag.String("tt", "bearer", "Token Type: specifies the Github's token type you want to use")
+	githubFlags(flag.CommandLine, &opt)
+	fix := flag.Bool("fix", false, "Fix: applies suggested fixes to the CODEOWNERS file")
+	diff := flag.Bool("diff", false, "Diff: prints the suggested fixes as a diff without changing the CODEOWNERS file")
+	enable := flag.String("enable", "", "Enable: comma separated list of checkers to run, even if disabled in the configuration")
+	disable := flag.String("disable", "", "Disable: comma separated list of checkers not to run")
+	listCheckers := flag.Bool("list-checkers", false, "List Checkers: prints the registered checkers and whether they will run")
+	timeout := flag.Duration("timeout", 0, "Timeout: specifies how long the linter may run, such as 30s or 2m, no limit by default")
+	accessFailures := flag.String("access-failures", "", "Access Failures: specifies how owners whose access could not be checked are reported: error (default), warning or skip")
+	accessConcurrency := flag.Int("access-concurrency", 0, "Access Concurrency: specifies how many owners are looked up in parallel, 8 by default")
+	accessSnapshot := flag.String("access-snapshot", "", "Access Snapshot: specifies a snapshot file to check owners against instead of calling Github, see the snapshot command")
+	cacheDir := flag.String("cache-dir", "", "Cache Dir: specifies a directory where owner access is cached between runs, nothing is cached by default")
+	cacheTTL := flag.Duration("cache-ttl", 0, "Cache TTL: specifies how long cached owner access is trusted, such as 30m or 24h, 1h by default")
+	noCache := flag.Bool("no-cache", false, "No Cache: disables the owner access cache, even if set in the configuration")
+	config := flag.String("c", "", "Config: specifies the configuration file, by default .codeownerslint.yaml is searched next to the CODEOWNERS file")
 	flag.Parse()
 
-	opt := options{
-		directory: *dir,
-		format:    *format,
-		token:     *token,
-		tokenType: *tokenType,
+	opt.directory = *dir
+	opt.format = *format
+	opt.fix = *fix
+	opt.diff = *diff
+	opt.config = *config
+	opt.enable = splitList(*enable)
+	opt.disable = splitList(*disable)
+	opt.timeout = *timeout
+	opt.accessFailures = *accessFailures
+	opt.accessConcurrency = *accessConcurrency
+	opt.accessSnapshot = *accessSnapshot
+	opt.cacheDir = *cacheDir
+	opt.cacheTTL = *cacheTTL
+	opt.noCache = *noCache
+
+	if len(opt.accessSnapshot) == 0 {
+		home, _ := os.UserHomeDir()
+		opt.tokenErr = resolveToken(os.Stderr, &opt, os.Getenv, home)
+	}
+	if *listCheckers {
+		os.Exit(int(runListCheckers(os.Stdout, opt)))
 	}
 	exitCode := run(os.Stderr, opt)
 	if exitCode == successCode {
@@ -28,3 +67,78 @@ func main() {
 	}
 	os.Exit(int(exitCode))
 }
+
+// githubFlags registers the flags telling how to reach Github or GitLab and which repository to check
+func githubFlags(flags *flag.FlagSet, opt *options) {
+	flags.StringVar(&opt.platform, "platform", "github", "Platform: specifies the platform hosting the repository, github, gitlab, gitea or bitbucket, it tells where the CODEOWNERS file is, its syntax and where owners are looked up")
+	flags.StringVar(&opt.token, "t", "", "Token: specifies the Github's token you want to use, by default it is read from GH_TOKEN, GITHUB_TOKEN (GH_ENTERPRISE_TOKEN, GITHUB_ENTERPRISE_TOKEN on Github Enterprise Server), the gh CLI or ~/.netrc")
+	flags.StringVar(&opt.tokenType, "tt", "bearer", "Token Type: specifies the Github's token type you want to use")
+	flags.StringVar(&opt.githubURL, "github-url", "", "Github URL: specifies the API base URL of your Github Enterprise Server, such as https://github.example.com/api/v3/")
+	flags.StringVar(&opt.githubUploadURL, "github-upload-url", "", "Github Upload URL: specifies the upload base URL of your Github Enterprise Server, defaults to -github-url")
+	flags.StringVar(&opt.caFile, "ca-file", "", "CA File: specifies a PEM file of extra certificate authorities to trust when calling the platform")
+	flags.StringVar(&opt.proxy, "proxy", "", "Proxy: specifies the proxy URL to use when calling the platform, defaults to the HTTPS_PROXY environment variable")
+	flags.StringVar(&opt.repo, "repo", "", "Repository: specifies the repository as owner/name, or the GitLab project as group/name, by default it is resolved from the git remote")
+	flags.StringVar(&opt.remote, "remote", "origin", "Remote: specifies the git remote the repository is resolved from")
+	flags.Int64Var(&opt.githubAppID, "github-app-id", 0, "Github App ID: authenticates as this Github App instead of using a token")
+	flags.StringVar(&opt.githubAppKey, "github-app-key", "", "Github App Key: specifies the PEM file of the Github App private key")
+	flags.Int64Var(&opt.githubAppInstallationID, "github-app-installation-id", 0, "Github App Installation ID: specifies the installation of the Github App, by default the installation of the repository")
+	flags.StringVar(&opt.gitlabURL, "gitlab-url", "", "GitLab URL: specifies the API base URL of your self-managed GitLab, such as https://gitlab.example.com/api/v4")
+	flags.StringVar(&opt.gitlabToken, "gitlab-token", "", "GitLab Token: specifies the GitLab token you want to use, by default it is read from GITLAB_TOKEN or ~/.netrc")
+	flags.StringVar(&opt.giteaURL, "gitea-url", "", "Gitea URL: specifies the API base URL of your Gitea or Forgejo server, such as https://gitea.example.com/api/v1")
+	flags.StringVar(&opt.giteaToken, "gitea-token", "", "Gitea Token: specifies the Gitea token you want to use, by default it is read from GITEA_TOKEN or ~/.netrc")
+	flags.BoolVar(&opt.verbose, "v", false, "Verbose: prints where the token was found")
+}
+
+func resolveTokenOrExit(opt *options) {
+	home, _ := os.UserHomeDir()
+	err := resolveToken(os.Stderr, opt, os.Getenv, home)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Unexpected error when discovering token: %v", err)
+		os.Exit(int(unexpectedErrorCode))
+	}
+}
+
+func splitList(list string) []string {
+	items := []string{}
+	for _, item := range strings.Split(list, ",") {
+		item = strings.TrimSpace(item)
+		if len(item) > 0 {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func formatMain(args []string) {
+	flags := flag.NewFlagSet("fmt", flag.ExitOnError)
+	dir := flags.String("d", ".", "Directory: specifies the directory you want to use to format the CODEOWNERS file")
+	check := flags.Bool("check", false, "Check: reports a diff instead of writing the file when it is not formatted")
+	platform := flags.String("platform", "github", "Platform: specifies the platform hosting the repository, it tells where the CODEOWNERS file is and its syntax")
+	flags.Parse(args)
+
+	opt := formatOptions{
+		directory: *dir,
+		check:     *check,
+		platform:  *platform,
+	}
+	exitCode := runFormat(os.Stdout, opt)
+	if exitCode != successCode {
+		os.Exit(int(exitCode))
+	}
+}
+
+func snapshotMain(args []string) {
+	opt := snapshotOptions{}
+	flags := flag.NewFlagSet("snapshot", flag.ExitOnError)
+	flags.StringVar(&opt.directory, "d", ".", "Directory: specifies the directory of the repository and its CODEOWNERS file")
+	githubFlags(flags, &opt.options)
+	flags.StringVar(&opt.output, "o", "", "Output: specifies the file to write the snapshot to, by default it is printed")
+	flags.StringVar(&opt.snapshotFormat, "format", "", "Format: specifies the snapshot format, json or yaml, by default json unless -o ends with .yaml or .yml")
+	flags.Parse(args)
+
+	resolveTokenOrExit(&opt.options)
+	exitCode := runSnapshot(os.Stdout, os.Stderr, opt)
+	if exitCode != successCode {
+		os.Exit(int(exitCode))
+	}
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/fmenezes/codeowners"
+	"github.com/fmenezes/codeowners/checkers"
+)
+
+type snapshotOptions struct {
+	options
+	output         string
+	snapshotFormat string
+}
+
+// snapshotEncoding returns the format of the snapshot, the one given or else the one of the output file extension
+func snapshotEncoding(opt snapshotOptions) (string, error) {
+	switch opt.snapshotFormat {
+	case "json", "yaml":
+		return opt.snapshotFormat, nil
+	case "":
+	default:
+		return "", fmt.Errorf("Unknown snapshot format '%s'", opt.snapshotFormat)
+	}
+	switch strings.ToLower(filepath.Ext(opt.output)) {
+	case ".yaml", ".yml":
+		return "yaml", nil
+	}
+	return "json", nil
+}
+
+// emailOwners lists the distinct email owners of the CODEOWNERS file, they can only be recorded when looked up one by one
+func emailOwners(file *codeowners.File) []string {
+	emails := []string{}
+	seen := make(map[string]bool)
+	for _, node := range file.Rules() {
+		for _, owner := range node.Owners {
+			if strings.HasPrefix(owner.Value, "@") || !strings.Contains(owner.Value, "@") || seen[owner.Value] {
+				continue
+			}
+			seen[owner.Value] = true
+			emails = append(emails, owner.Value)
+		}
+	}
+	return emails
+}
+
+// runSnapshot takes a snapshot of the repository access, writing it to the output file or to out
+func runSnapshot(out io.Writer, wr io.Writer, opt snapshotOptions) exitCode {
+	dir, err := filepath.Abs(opt.directory)
+	if err != nil {
+		fmt.Fprintf(wr, "Unexpected error when parsing directory: %v", err)
+		return unexpectedErrorCode
+	}
+
+	format, err := snapshotEncoding(opt)
+	if err != nil {
+		fmt.Fprintf(wr, "Unexpected error when selecting format: %v", err)
+		return unexpectedErrorCode
+	}
+
+	if len(opt.platform) > 0 && opt.platform != codeowners.GithubPlatform {
+		fmt.Fprint(wr, "Unexpected error when taking snapshot: Snapshots are only supported on Github")
+		return unexpectedErrorCode
+	}
+	if len(opt.token) == 0 && opt.githubAppID == 0 {
+		fmt.Fprint(wr, "Unexpected error when taking snapshot: No Github token found, see -t")
+		return unexpectedErrorCode
+	}
+
+	emails := []string{}
+	if fileLocation, err := codeowners.FindCodeownersFile(dir); err == nil {
+		content, err := ioutil.ReadFile(filepath.Join(dir, fileLocation))
+		if err != nil {
+			fmt.Fprintf(wr, "Unexpected error when reading CODEOWNERS file: %v", err)
+			return unexpectedErrorCode
+		}
+		file, err := codeowners.Parse(bytes.NewReader(content))
+		if err != nil {
+			fmt.Fprintf(wr, "Unexpected error when parsing CODEOWNERS file: %v", err)
+			return unexpectedErrorCode
+		}
+		emails = emailOwners(file)
+	}
+
+	checkOptions, err := buildCheckOptions(dir, opt.options, &codeowners.Config{})
+	if err != nil {
+		fmt.Fprintf(wr, "Unexpected error when taking snapshot: %v", err)
+		return unexpectedErrorCode
+	}
+	snapshot, err := checkers.TakeSnapshot(codeowners.ValidatorOptions{
+		Directory:               dir,
+		GithubToken:             checkOptions.GithubToken,
+		GithubTokenType:         checkOptions.GithubTokenType,
+		GithubURL:               checkOptions.GithubURL,
+		GithubUploadURL:         checkOptions.GithubUploadURL,
+		CAFile:                  checkOptions.CAFile,
+		Proxy:                   checkOptions.Proxy,
+		GithubAppID:             checkOptions.GithubAppID,
+		GithubAppPrivateKeyFile: checkOptions.GithubAppPrivateKeyFile,
+		GithubAppInstallationID: checkOptions.GithubAppInstallationID,
+		Repository:              checkOptions.Repository,
+		Remote:                  checkOptions.Remote,
+	}, emails)
+	if err != nil {
+		fmt.Fprintf(wr, "Unexpected error when taking snapshot: %v", err)
+		return unexpectedErrorCode
+	}
+
+	if len(opt.output) == 0 {
+		err = snapshot.Encode(out, format)
+	} else {
+		var encoded bytes.Buffer
+		err = snapshot.Encode(&encoded, format)
+		if err == nil {
+			err = ioutil.WriteFile(opt.output, encoded.Bytes(), 0644)
+		}
+	}
+	if err != nil {
+		fmt.Fprintf(wr, "Unexpected error when writing snapshot: %v", err)
+		return unexpectedErrorCode
+	}
+	if opt.verbose {
+		fmt.Fprintf(wr, "Snapshot of %s taken with %d users and %d teams\n", snapshot.Repository, len(snapshot.Users), len(snapshot.Teams))
+	}
+	return successCode
+}
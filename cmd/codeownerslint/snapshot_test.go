@@ -0,0 +1,107 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/fmenezes/codeowners"
+	"github.com/fmenezes/codeowners/checkers"
+)
+
+func TestAccessSnapshot(t *testing.T) {
+	assert(t, options{
+		directory:      "../../test/data/snapshot",
+		accessSnapshot: "../../test/fixtures/snapshot/snapshot.yaml",
+	}, errorCode, `CODEOWNERS 2:11-18 ::Error:: Owner '@reader' has no write access to owner/repo [Access]
+`)
+}
+
+func TestAccessSnapshotOtherRepository(t *testing.T) {
+	assert(t, options{
+		directory:      "../../test/data/snapshot",
+		accessSnapshot: "../../test/fixtures/snapshot/snapshot.yaml",
+		repo:           "other/repo",
+	}, errorCode, `CODEOWNERS 0 ::Error:: Could not check access of owners: Snapshot of owner/repo does not match the repository other/repo [AccessCheckFailed]
+`)
+}
+
+func TestAccessSnapshotOtherPlatform(t *testing.T) {
+	assert(t, options{
+		directory:      "../../test/data/snapshot",
+		accessSnapshot: "../../test/fixtures/snapshot/snapshot.yaml",
+		platform:       "gitlab",
+	}, errorCode, `CODEOWNERS 0 ::Error:: Could not check access of owners: Snapshots are only supported on Github, not on gitlab [AccessCheckFailed]
+`)
+}
+
+func TestSnapshot(t *testing.T) {
+	dir, err := ioutil.TempDir("", "codeowners")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	tests := []struct {
+		output string
+		format string
+	}{
+		{output: "", format: ""},
+		{output: filepath.Join(dir, "snapshot.json"), format: ""},
+		{output: filepath.Join(dir, "snapshot.yml"), format: ""},
+		{output: filepath.Join(dir, "snapshot.txt"), format: "yaml"},
+	}
+	for _, test := range tests {
+		var out, wr bytes.Buffer
+		opt := snapshotOptions{output: test.output, snapshotFormat: test.format}
+		opt.directory = "../../test/data/pass"
+		opt.token = "token"
+		if code := runSnapshot(&out, &wr, opt); code != successCode {
+			t.Fatalf("Input: %v, Want: %v, Got: %v %s", test, successCode, code, wr.String())
+		}
+		if len(test.output) == 0 {
+			if !strings.Contains(out.String(), `"repository": "owner/repo"`) {
+				t.Errorf("Input: %v, Want: a JSON snapshot of owner/repo, Got: %s", test, out.String())
+			}
+			continue
+		}
+		snapshot, err := checkers.LoadSnapshot(test.output)
+		if err != nil || snapshot.Repository != "owner/repo" {
+			t.Errorf("Input: %v, Want: a snapshot of owner/repo, Got: %v %v", test, snapshot, err)
+		}
+	}
+}
+
+func TestSnapshotErrors(t *testing.T) {
+	tests := []snapshotOptions{
+		{snapshotFormat: "xml"},
+		{},
+		{},
+	}
+	tests[0].token = "token"
+	tests[2].platform = codeowners.GitlabPlatform
+	tests[2].gitlabToken = "token"
+	for _, opt := range tests {
+		opt.directory = "../../test/data/pass"
+		var out, wr bytes.Buffer
+		if code := runSnapshot(&out, &wr, opt); code != unexpectedErrorCode {
+			t.Errorf("Input: %v, Want: %v, Got: %v", opt, unexpectedErrorCode, code)
+		}
+	}
+}
+
+func TestEmailOwners(t *testing.T) {
+	file, err := codeowners.Parse(strings.NewReader("* @owner a@example.com\ndocs/ b@example.com a@example.com invalid\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"a@example.com", "b@example.com"}
+	got := emailOwners(file)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Want: %v, Got: %v", want, got)
+	}
+}
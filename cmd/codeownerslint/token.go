@@ -0,0 +1,236 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/fmenezes/codeowners"
+	"gopkg.in/yaml.v2"
+)
+
+// tokenEnvVars are the environment variables holding a github.com token, in order of precedence
+var tokenEnvVars = []string{"GH_TOKEN", "GITHUB_TOKEN"}
+
+// enterpriseTokenEnvVars are the environment variables holding a Github Enterprise Server token, in order of precedence,
+// like the gh CLI they are used for every host but github.com
+var enterpriseTokenEnvVars = []string{"GH_ENTERPRISE_TOKEN", "GITHUB_ENTERPRISE_TOKEN"}
+
+// githubHost returns the host of the Github instance, github.com unless a Github Enterprise Server URL is given
+func githubHost(githubURL string) string {
+	return apiHost(githubURL, "github.com")
+}
+
+// gitlabHost returns the host of the GitLab instance, gitlab.com unless a self-managed GitLab URL is given
+func gitlabHost(gitlabURL string) string {
+	return apiHost(gitlabURL, "gitlab.com")
+}
+
+// apiHost returns the host name of apiURL, or defaultHost when it is empty or invalid
+func apiHost(apiURL, defaultHost string) string {
+	if len(apiURL) == 0 {
+		return defaultHost
+	}
+	u, err := url.Parse(apiURL)
+	if err != nil || len(u.Hostname()) == 0 {
+		return defaultHost
+	}
+	return u.Hostname()
+}
+
+// resolveToken fills the token of opt when neither a token nor a Github App is given, see discoverToken.
+// On GitLab and Gitea the token of the platform is filled instead, see resolveForgeToken.
+// In verbose mode it reports where the token came from, never the token itself.
+func resolveToken(wr io.Writer, opt *options, getenv func(string) string, home string) error {
+	switch opt.platform {
+	case codeowners.GitlabPlatform:
+		forge := forgeToken{name: "GitLab", flag: "-gitlab-token", envVar: "GITLAB_TOKEN", host: gitlabHost(opt.gitlabURL), token: &opt.gitlabToken}
+		return resolveForgeToken(wr, opt.verbose, forge, getenv, home)
+	case codeowners.GiteaPlatform:
+		forge := forgeToken{name: "Gitea", flag: "-gitea-token", envVar: "GITEA_TOKEN", host: apiHost(opt.giteaURL, ""), token: &opt.giteaToken}
+		return resolveForgeToken(wr, opt.verbose, forge, getenv, home)
+	case codeowners.BitbucketPlatform:
+		return nil // owners are not looked up on Bitbucket
+	}
+	if len(opt.token) > 0 {
+		if opt.verbose {
+			fmt.Fprintln(wr, "Using Github token from -t")
+		}
+		return nil
+	}
+	if opt.githubAppID != 0 {
+		if opt.verbose {
+			fmt.Fprintf(wr, "Using Github App %d\n", opt.githubAppID)
+		}
+		return nil
+	}
+
+	host := githubHost(opt.githubURL)
+	token, source, err := discoverToken(host, getenv, home)
+	if err != nil {
+		return err
+	}
+	opt.token = token
+	if opt.verbose {
+		if len(token) > 0 {
+			fmt.Fprintf(wr, "Using Github token for %s from %s\n", host, source)
+		} else {
+			fmt.Fprintf(wr, "No Github token found for %s\n", host)
+		}
+	}
+	return nil
+}
+
+// forgeToken tells where the token of a platform other than Github is given and discovered
+type forgeToken struct {
+	name   string // name is the name of the platform shown in verbose messages
+	flag   string
+	envVar string
+	host   string
+	token  *string // token is the option the token is given in and filled into
+}
+
+// resolveForgeToken fills the token of forge when none is given, looking in order at
+// its environment variable and the netrc entry of its host
+func resolveForgeToken(wr io.Writer, verbose bool, forge forgeToken, getenv func(string) string, home string) error {
+	if len(*forge.token) > 0 {
+		if verbose {
+			fmt.Fprintf(wr, "Using %s token from %s\n", forge.name, forge.flag)
+		}
+		return nil
+	}
+
+	token, source := getenv(forge.envVar), forge.envVar
+	if len(token) == 0 {
+		var err error
+		source = getenv("NETRC")
+		if len(source) == 0 {
+			source = filepath.Join(home, ".netrc")
+		}
+		token, err = netrcToken(source, forge.host)
+		if err != nil {
+			return err
+		}
+	}
+	*forge.token = token
+	if verbose {
+		if len(token) > 0 {
+			fmt.Fprintf(wr, "Using %s token for %s from %s\n", forge.name, forge.host, source)
+		} else {
+			fmt.Fprintf(wr, "No %s token found for %s\n", forge.name, forge.host)
+		}
+	}
+	return nil
+}
+
+// discoverToken finds a Github token for host when none is given, looking in order at
+// the environment, the gh CLI hosts.yml and the netrc file. It returns the token and where it was found.
+// Tokens of the environment are only used for github.com, or for other hosts when they are enterprise tokens.
+func discoverToken(host string, getenv func(string) string, home string) (string, string, error) {
+	envVars := tokenEnvVars
+	if !strings.EqualFold(host, "github.com") {
+		envVars = enterpriseTokenEnvVars
+	}
+	for _, name := range envVars {
+		if token := getenv(name); len(token) > 0 {
+			return token, name, nil
+		}
+	}
+
+	hostsFile := filepath.Join(ghConfigDir(getenv, home), "hosts.yml")
+	token, err := ghHostsToken(hostsFile, host)
+	if err != nil {
+		return "", "", err
+	}
+	if len(token) > 0 {
+		return token, hostsFile, nil
+	}
+
+	netrcFile := getenv("NETRC")
+	if len(netrcFile) == 0 {
+		netrcFile = filepath.Join(home, ".netrc")
+	}
+	token, err = netrcToken(netrcFile, host)
+	if err != nil {
+		return "", "", err
+	}
+	if len(token) > 0 {
+		return token, netrcFile, nil
+	}
+
+	return "", "", nil
+}
+
+// ghConfigDir returns the configuration directory of the gh CLI
+func ghConfigDir(getenv func(string) string, home string) string {
+	if dir := getenv("GH_CONFIG_DIR"); len(dir) > 0 {
+		return dir
+	}
+	if dir := getenv("XDG_CONFIG_HOME"); len(dir) > 0 {
+		return filepath.Join(dir, "gh")
+	}
+	return filepath.Join(home, ".config", "gh")
+}
+
+// ghHostsToken returns the token the gh CLI keeps for host in hosts.yml, a missing file has no token
+func ghHostsToken(file, host string) (string, error) {
+	content, err := ioutil.ReadFile(file)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	hosts := map[string]struct {
+		OAuthToken string `yaml:"oauth_token"`
+	}{}
+	err = yaml.Unmarshal(content, &hosts)
+	if err != nil {
+		return "", err
+	}
+	return hosts[host].OAuthToken, nil
+}
+
+// netrcToken returns the password of host in a netrc file, falling back to the default entry, a missing file has no token
+func netrcToken(file, host string) (string, error) {
+	content, err := ioutil.ReadFile(file)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	passwords := make(map[string]string)
+	machine := ""
+	fields := strings.Fields(string(content))
+	for i := 0; i < len(fields); i++ {
+		switch fields[i] {
+		case "machine":
+			if i+1 < len(fields) {
+				i++
+				machine = fields[i]
+			}
+		case "default":
+			machine = "default"
+		case "password":
+			if i+1 < len(fields) && len(machine) > 0 {
+				i++
+				if _, found := passwords[machine]; !found {
+					passwords[machine] = fields[i]
+				}
+			}
+		case "login", "account":
+			i++ // skips the value, it may look like a keyword
+		}
+	}
+
+	if password, found := passwords[host]; found {
+		return password, nil
+	}
+	return passwords["default"], nil
+}
@@ -0,0 +1,208 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/fmenezes/codeowners"
+)
+
+func tokenHome(t *testing.T, hosts, netrc string) string {
+	t.Helper()
+	home, err := ioutil.TempDir("", "codeowners")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(home) })
+	if len(hosts) > 0 {
+		err = os.MkdirAll(filepath.Join(home, ".config", "gh"), 0755)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = ioutil.WriteFile(filepath.Join(home, ".config", "gh", "hosts.yml"), []byte(hosts), 0600)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(netrc) > 0 {
+		err = ioutil.WriteFile(filepath.Join(home, ".netrc"), []byte(netrc), 0600)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	return home
+}
+
+func fakeEnv(env map[string]string) func(string) string {
+	return func(name string) string {
+		return env[name]
+	}
+}
+
+const testHosts = `github.com:
+    user: octocat
+    oauth_token: gh-token
+    git_protocol: https
+github.example.com:
+    oauth_token: ghe-token
+`
+
+const testNetrc = `machine github.com
+  login octocat
+  password netrc-token
+machine github.example.com login octocat password netrc-ghe-token
+`
+
+func TestDiscoverToken(t *testing.T) {
+	tests := []struct {
+		name       string
+		host       string
+		env        map[string]string
+		hosts      string
+		netrc      string
+		wantToken  string
+		wantSource string
+	}{
+		{name: "gh token first", host: "github.com", env: map[string]string{"GH_TOKEN": "a", "GITHUB_TOKEN": "b"}, hosts: testHosts, netrc: testNetrc, wantToken: "a", wantSource: "GH_TOKEN"},
+		{name: "github token", host: "github.com", env: map[string]string{"GITHUB_TOKEN": "b"}, hosts: testHosts, netrc: testNetrc, wantToken: "b", wantSource: "GITHUB_TOKEN"},
+		{name: "gh hosts", host: "github.com", hosts: testHosts, netrc: testNetrc, wantToken: "gh-token", wantSource: filepath.Join(".config", "gh", "hosts.yml")},
+		{name: "gh hosts enterprise", host: "github.example.com", hosts: testHosts, wantToken: "ghe-token", wantSource: filepath.Join(".config", "gh", "hosts.yml")},
+		{name: "netrc", host: "github.com", netrc: testNetrc, wantToken: "netrc-token", wantSource: ".netrc"},
+		{name: "netrc enterprise", host: "github.example.com", hosts: "other.example.com:\n    oauth_token: x\n", netrc: testNetrc, wantToken: "netrc-ghe-token", wantSource: ".netrc"},
+		{name: "netrc default", host: "github.com", netrc: "machine other.example.com password x\ndefault login me password default-token\n", wantToken: "default-token", wantSource: ".netrc"},
+		{name: "gh token not for enterprise", host: "github.example.com", env: map[string]string{"GH_TOKEN": "a", "GITHUB_TOKEN": "b"}, hosts: testHosts, wantToken: "ghe-token", wantSource: filepath.Join(".config", "gh", "hosts.yml")},
+		{name: "enterprise token first", host: "github.example.com", env: map[string]string{"GH_ENTERPRISE_TOKEN": "c", "GITHUB_ENTERPRISE_TOKEN": "d"}, hosts: testHosts, wantToken: "c", wantSource: "GH_ENTERPRISE_TOKEN"},
+		{name: "github enterprise token", host: "github.example.com", env: map[string]string{"GITHUB_ENTERPRISE_TOKEN": "d"}, hosts: testHosts, wantToken: "d", wantSource: "GITHUB_ENTERPRISE_TOKEN"},
+		{name: "enterprise token not for github", host: "github.com", env: map[string]string{"GH_ENTERPRISE_TOKEN": "c"}, hosts: testHosts, wantToken: "gh-token", wantSource: filepath.Join(".config", "gh", "hosts.yml")},
+		{name: "nothing", host: "github.com", netrc: "machine other.example.com password x\n", wantToken: "", wantSource: ""},
+	}
+	for _, test := range tests {
+		home := tokenHome(t, test.hosts, test.netrc)
+		gotToken, gotSource, err := discoverToken(test.host, fakeEnv(test.env), home)
+		if err != nil {
+			t.Errorf("Input: %v, Want: %v, Got: %v", test.name, nil, err)
+			continue
+		}
+		wantSource := test.wantSource
+		if strings.HasPrefix(wantSource, ".") {
+			wantSource = filepath.Join(home, wantSource)
+		}
+		if gotToken != test.wantToken || gotSource != wantSource {
+			t.Errorf("Input: %v, Want: %v %v, Got: %v %v", test.name, test.wantToken, wantSource, gotToken, gotSource)
+		}
+	}
+}
+
+func TestDiscoverTokenConfigDirs(t *testing.T) {
+	home := tokenHome(t, testHosts, "")
+	configDir := filepath.Join(home, ".config", "gh")
+	netrc := filepath.Join(home, "custom-netrc")
+	err := ioutil.WriteFile(netrc, []byte(testNetrc), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	token, _, _ := discoverToken("github.com", fakeEnv(map[string]string{"GH_CONFIG_DIR": configDir}), "/nonexistent")
+	if token != "gh-token" {
+		t.Errorf("Input: GH_CONFIG_DIR, Want: %v, Got: %v", "gh-token", token)
+	}
+	token, _, _ = discoverToken("github.com", fakeEnv(map[string]string{"XDG_CONFIG_HOME": filepath.Join(home, ".config")}), "/nonexistent")
+	if token != "gh-token" {
+		t.Errorf("Input: XDG_CONFIG_HOME, Want: %v, Got: %v", "gh-token", token)
+	}
+	token, _, _ = discoverToken("github.com", fakeEnv(map[string]string{"NETRC": netrc}), "/nonexistent")
+	if token != "netrc-token" {
+		t.Errorf("Input: NETRC, Want: %v, Got: %v", "netrc-token", token)
+	}
+}
+
+func TestDiscoverTokenInvalidHosts(t *testing.T) {
+	home := tokenHome(t, "github.com: [", "")
+	_, _, err := discoverToken("github.com", fakeEnv(nil), home)
+	if err == nil {
+		t.Errorf("Want: error, Got: %v", err)
+	}
+}
+
+func TestRunInvalidHostsWithoutAccess(t *testing.T) {
+	tokenErr := errors.New("invalid hosts.yml")
+	assertCode(t, options{directory: "../../test/data/pass", tokenErr: tokenErr, disable: []string{"Access"}}, successCode)
+	assert(t, options{directory: "../../test/data/pass", tokenErr: tokenErr}, unexpectedErrorCode,
+		"Unexpected error when discovering token: invalid hosts.yml")
+}
+
+func TestResolveTokenVerbose(t *testing.T) {
+	tests := []struct {
+		opt       options
+		env       map[string]string
+		wantToken string
+		wantLog   string
+	}{
+		{opt: options{token: "flag-token", verbose: true}, env: map[string]string{"GH_TOKEN": "env-token"}, wantToken: "flag-token", wantLog: "Using Github token from -t\n"},
+		{opt: options{verbose: true}, env: map[string]string{"GH_TOKEN": "env-token"}, wantToken: "env-token", wantLog: "Using Github token for github.com from GH_TOKEN\n"},
+		{opt: options{verbose: true, githubURL: "https://github.example.com/api/v3/"}, wantToken: "", wantLog: "No Github token found for github.example.com\n"},
+		{opt: options{verbose: true, githubAppID: 12}, env: map[string]string{"GH_TOKEN": "env-token"}, wantToken: "", wantLog: "Using Github App 12\n"},
+		{opt: options{}, env: map[string]string{"GITHUB_TOKEN": "env-token"}, wantToken: "env-token", wantLog: ""},
+	}
+	for _, test := range tests {
+		var output bytes.Buffer
+		opt := test.opt
+		err := resolveToken(&output, &opt, fakeEnv(test.env), tokenHome(t, "", ""))
+		if err != nil || opt.token != test.wantToken || output.String() != test.wantLog {
+			t.Errorf("Input: %v, Want: %v '%v', Got: %v '%v' %v", test.opt, test.wantToken, test.wantLog, opt.token, output.String(), err)
+		}
+	}
+}
+
+func TestResolveGitlabToken(t *testing.T) {
+	const gitlabNetrc = "machine gitlab.example.com login octocat password netrc-gitlab-token\n"
+	tests := []struct {
+		opt       options
+		env       map[string]string
+		wantToken string
+		wantLog   string
+	}{
+		{opt: options{gitlabToken: "flag-token", verbose: true}, env: map[string]string{"GITLAB_TOKEN": "env-token"}, wantToken: "flag-token", wantLog: "Using GitLab token from -gitlab-token\n"},
+		{opt: options{verbose: true}, env: map[string]string{"GITLAB_TOKEN": "env-token", "GH_TOKEN": "gh-token"}, wantToken: "env-token", wantLog: "Using GitLab token for gitlab.com from GITLAB_TOKEN\n"},
+		{opt: options{verbose: true}, env: map[string]string{"GH_TOKEN": "gh-token"}, wantToken: "", wantLog: "No GitLab token found for gitlab.com\n"},
+		{opt: options{gitlabURL: "https://gitlab.example.com/api/v4"}, wantToken: "netrc-gitlab-token", wantLog: ""},
+	}
+	for _, test := range tests {
+		var output bytes.Buffer
+		opt := test.opt
+		opt.platform = codeowners.GitlabPlatform
+		err := resolveToken(&output, &opt, fakeEnv(test.env), tokenHome(t, "", gitlabNetrc))
+		if err != nil || opt.gitlabToken != test.wantToken || len(opt.token) > 0 || output.String() != test.wantLog {
+			t.Errorf("Input: %v, Want: %v '%v', Got: %v '%v' %v", test.opt, test.wantToken, test.wantLog, opt.gitlabToken, output.String(), err)
+		}
+	}
+}
+
+func TestResolveGiteaToken(t *testing.T) {
+	const giteaNetrc = "machine gitea.example.com login octocat password netrc-gitea-token\n"
+	tests := []struct {
+		opt       options
+		env       map[string]string
+		wantToken string
+		wantLog   string
+	}{
+		{opt: options{giteaToken: "flag-token", verbose: true}, env: map[string]string{"GITEA_TOKEN": "env-token"}, wantToken: "flag-token", wantLog: "Using Gitea token from -gitea-token\n"},
+		{opt: options{verbose: true, giteaURL: "https://gitea.example.com/api/v1"}, env: map[string]string{"GITEA_TOKEN": "env-token"}, wantToken: "env-token", wantLog: "Using Gitea token for gitea.example.com from GITEA_TOKEN\n"},
+		{opt: options{giteaURL: "https://gitea.example.com/api/v1"}, wantToken: "netrc-gitea-token", wantLog: ""},
+		{opt: options{verbose: true, giteaURL: "https://forgejo.example.com/api/v1"}, env: map[string]string{"GITLAB_TOKEN": "gitlab-token"}, wantToken: "", wantLog: "No Gitea token found for forgejo.example.com\n"},
+	}
+	for _, test := range tests {
+		var output bytes.Buffer
+		opt := test.opt
+		opt.platform = codeowners.GiteaPlatform
+		err := resolveToken(&output, &opt, fakeEnv(test.env), tokenHome(t, "", giteaNetrc))
+		if err != nil || opt.giteaToken != test.wantToken || len(opt.token) > 0 || output.String() != test.wantLog {
+			t.Errorf("Input: %v, Want: %v '%v', Got: %v '%v' %v", test.opt, test.wantToken, test.wantLog, opt.giteaToken, output.String(), err)
+		}
+	}
+}
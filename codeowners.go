@@ -3,10 +3,11 @@ package codeowners // import "github.com/fmenezes/codeowners"
 
 import (
 	"strings"
-	"unicode"
 )
 
-// DefaultLocations provides default locations for the CODEOWNERS file
+// DefaultLocations provides default locations for the CODEOWNERS file.
+//
+// Deprecated: platforms differ in where they look for the file and in which order, see Profile.
 var DefaultLocations = [...]string{"CODEOWNERS", "docs/CODEOWNERS", ".github/CODEOWNERS"}
 
 // sanitiseLine removes all empty space and comments from a given line
@@ -20,20 +21,11 @@ func sanitiseLine(line string) string {
 
 // ParseLine parses a CODEOWNERS line into file pattern and owners
 func ParseLine(line string) (string, []string) {
-	line = sanitiseLine(line)
+	node := ParseNode(0, line)
 
-	var previousRune rune
-	data := strings.FieldsFunc(line, func(r rune) bool {
-		result := unicode.IsSpace(r) && previousRune != '\\'
-		previousRune = r
-		return result
-	})
-
-	if len(data) > 1 {
-		return data[0], data[1:]
-	} else if len(data) == 1 {
-		return data[0], nil
-	} else {
-		return "", nil
+	var owners []string
+	for _, owner := range node.Owners {
+		owners = append(owners, owner.Value)
 	}
+	return node.Pattern.Value, owners
 }
@@ -0,0 +1,112 @@
+package codeowners
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"gopkg.in/yaml.v2"
+)
+
+// ConfigFileNames provides the file names searched when looking for a configuration file
+var ConfigFileNames = [...]string{".codeownerslint.yaml", ".codeownerslint.yml"}
+
+// Config provides the project settings read from a configuration file
+type Config struct {
+	Format   string                   `yaml:"format"`   // Format is the template used to write results
+	Checkers map[string]CheckerConfig `yaml:"checkers"` // Checkers provides the settings of each checker by name
+}
+
+// CheckerConfig provides the settings of a single checker
+type CheckerConfig struct {
+	Enabled  *bool             `yaml:"enabled"`  // Enabled is true unless disabled explicitly
+	Severity string            `yaml:"severity"` // Severity overrides the severity of this checker's results
+	Options  map[string]string `yaml:"options"`  // Options are passed to the checker through ValidatorOptions
+}
+
+// LoadConfig reads the configuration file at the given path.
+// Checkers must be registered beforehand, settings of unknown checkers are rejected.
+func LoadConfig(path string) (*Config, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	config := &Config{}
+	err = yaml.UnmarshalStrict(content, config)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid configuration file %s: %v", path, err)
+	}
+
+	known := checkNames(AvailableCheckers())
+	for _, name := range builtinCheckNames {
+		known[name] = true
+	}
+	for name, checker := range config.Checkers {
+		if !known[name] {
+			return nil, fmt.Errorf("Invalid configuration file %s: unknown checker %s", path, name)
+		}
+		if _, registered := availableCheckers[name]; !registered && (checker.Enabled != nil || len(checker.Options) > 0) {
+			return nil, fmt.Errorf("Invalid configuration file %s: %s is not a checker, only its severity can be set", path, name)
+		}
+		if optionsValidator, ok := availableCheckers[name].(OptionsValidator); ok {
+			if err = optionsValidator.ValidateOptions(checker.Options); err != nil {
+				return nil, fmt.Errorf("Invalid configuration file %s: checker %s: %v", path, name, err)
+			}
+		}
+		if len(checker.Severity) == 0 {
+			continue
+		}
+		if _, err = ParseSeverityLevel(checker.Severity); err != nil {
+			return nil, fmt.Errorf("Invalid configuration file %s: checker %s: %v", path, name, err)
+		}
+	}
+
+	return config, nil
+}
+
+// FindConfig looks for a configuration file next to the CODEOWNERS file, then at the root of the given directory.
+// It returns an empty path when there is none. The CODEOWNERS file is found following GithubProfile, see Profile.FindConfig.
+func FindConfig(dir string) string {
+	return GithubProfile.FindConfig(dir)
+}
+
+// Enabled returns true unless the checker was disabled
+func (c *Config) Enabled(checker string) bool {
+	enabled := c.Checkers[checker].Enabled
+	return enabled == nil || *enabled
+}
+
+// ApplyTo merges this configuration into the check options.
+// Disabled checkers are removed, settings already present in the options take precedence.
+func (c *Config) ApplyTo(options *CheckOptions) {
+	checkers := []string{}
+	for _, checker := range options.Checkers {
+		if c.Enabled(checker) {
+			checkers = append(checkers, checker)
+		}
+	}
+	options.Checkers = checkers
+
+	for name, checker := range c.Checkers {
+		if len(checker.Severity) > 0 {
+			if options.Severities == nil {
+				options.Severities = make(map[string]SeverityLevel)
+			}
+			if _, found := options.Severities[name]; !found {
+				options.Severities[name], _ = ParseSeverityLevel(checker.Severity)
+			}
+		}
+
+		for key, value := range checker.Options {
+			if options.CheckerOptions == nil {
+				options.CheckerOptions = make(map[string]map[string]string)
+			}
+			if options.CheckerOptions[name] == nil {
+				options.CheckerOptions[name] = make(map[string]string)
+			}
+			if _, found := options.CheckerOptions[name][key]; !found {
+				options.CheckerOptions[name][key] = value
+			}
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package codeowners_test
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/fmenezes/codeowners"
+)
+
+func TestFindConfig(t *testing.T) {
+	testCases := []struct {
+		input string
+		want  string
+	}{
+		{input: "./test/data/config", want: filepath.Join("test", "data", "config", ".github", ".codeownerslint.yaml")},
+		{input: "./test/data/pass", want: ""},
+	}
+
+	for _, testCase := range testCases {
+		got := codeowners.FindConfig(testCase.input)
+		if got != testCase.want {
+			t.Errorf("Input: %s, Want: %s, Got: %s", testCase.input, testCase.want, got)
+		}
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	codeowners.RegisterChecker("NoOwner", dummyChecker{})
+	codeowners.RegisterChecker("InvalidOwner", dummyChecker{})
+	disabled := false
+	want := &codeowners.Config{
+		Format: "{{ .Position.Format }} {{ .Severity.Name }} [{{ .CheckName }}]",
+		Checkers: map[string]codeowners.CheckerConfig{
+			"NoOwner":      {Enabled: &disabled},
+			"InvalidOwner": {Severity: "warning"},
+		},
+	}
+
+	got, err := codeowners.LoadConfig("./test/data/config/.github/.codeownerslint.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Want: %v, Got: %v", want, got)
+	}
+}
+
+func TestLoadConfigErrors(t *testing.T) {
+	codeowners.RegisterChecker("NoOwner", dummyChecker{})
+	for _, input := range []string{"./test/data/invalid_config.yaml", "./test/data/missing.yaml", "./test/data/pass/CODEOWNERS"} {
+		_, err := codeowners.LoadConfig(input)
+		if err == nil {
+			t.Errorf("Input: %s, Should have errored", input)
+		}
+	}
+}
+
+func TestLoadConfigBuiltinChecks(t *testing.T) {
+	tests := []struct {
+		input   string
+		wantErr string
+	}{
+		{input: "./test/data/builtin_config/severity.yaml"},
+		{input: "./test/data/builtin_config/enabled.yaml",
+			wantErr: "Invalid configuration file ./test/data/builtin_config/enabled.yaml: UnusedSuppression is not a checker, only its severity can be set"},
+		{input: "./test/data/builtin_config/options.yaml",
+			wantErr: "Invalid configuration file ./test/data/builtin_config/options.yaml: NoCodeowners is not a checker, only its severity can be set"},
+	}
+	for _, test := range tests {
+		_, err := codeowners.LoadConfig(test.input)
+		if (err == nil && len(test.wantErr) > 0) || (err != nil && err.Error() != test.wantErr) {
+			t.Errorf("Input: %s, Want: %s, Got: %v", test.input, test.wantErr, err)
+		}
+	}
+}
+
+func TestLoadConfigUnknownChecker(t *testing.T) {
+	input := "./test/data/unknown_checker_config.yaml"
+	want := "Invalid configuration file ./test/data/unknown_checker_config.yaml: unknown checker NoOwnr"
+	_, err := codeowners.LoadConfig(input)
+	if err == nil || err.Error() != want {
+		t.Errorf("Input: %s, Want: %s, Got: %v", input, want, err)
+	}
+}
+
+func TestConfigApplyTo(t *testing.T) {
+	disabled := false
+	config := &codeowners.Config{
+		Checkers: map[string]codeowners.CheckerConfig{
+			"A": {Enabled: &disabled},
+			"B": {Severity: "warning", Options: map[string]string{"key": "config", "other": "config"}},
+			"C": {Severity: "warning"},
+		},
+	}
+	options := codeowners.CheckOptions{
+		Checkers:       []string{"A", "B", "C"},
+		Severities:     map[string]codeowners.SeverityLevel{"C": codeowners.Error},
+		CheckerOptions: map[string]map[string]string{"B": {"key": "flag"}},
+	}
+	want := codeowners.CheckOptions{
+		Checkers:       []string{"B", "C"},
+		Severities:     map[string]codeowners.SeverityLevel{"B": codeowners.Warning, "C": codeowners.Error},
+		CheckerOptions: map[string]map[string]string{"B": {"key": "flag", "other": "config"}},
+	}
+
+	config.ApplyTo(&options)
+	if !reflect.DeepEqual(options, want) {
+		t.Errorf("Want: %v, Got: %v", want, options)
+	}
+}
+
+func TestParseSeverityLevel(t *testing.T) {
+	got, err := codeowners.ParseSeverityLevel("WARNING")
+	if err != nil || got != codeowners.Warning {
+		t.Errorf("Want: %v, Got: %v %v", codeowners.Warning, got, err)
+	}
+	_, err = codeowners.ParseSeverityLevel("critical")
+	if err == nil {
+		t.Error("Should have errored")
+	}
+}
@@ -0,0 +1,81 @@
+package codeowners
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Dialect tells how the rules of a CODEOWNERS file are read by the platform hosting the repository
+type Dialect int
+
+// All possible dialects
+const (
+	GithubDialect    Dialect = iota // GithubDialect patterns are gitignore style globs and the last matching rule wins
+	GiteaDialect                    // GiteaDialect patterns are Go regular expressions, negated by a leading '!', and the owners of every matching rule are combined
+	GitlabDialect                   // GitlabDialect patterns are GithubDialect globs grouped in sections, the last matching rule of every section wins and sections are combined
+	BitbucketDialect                // BitbucketDialect patterns are GithubDialect globs, owners may be @@groups with a reviewer selection strategy and groups may be defined as @@@group
+)
+
+// Name returns the string representation of this dialect
+func (d Dialect) Name() string {
+	return [...]string{"Github", "Gitea", "Gitlab", "Bitbucket"}[d]
+}
+
+// CombinesMatches tells whether a path is owned by the owners of every rule matching it, rather than only by those of the last one
+func (d Dialect) CombinesMatches() bool {
+	return d == GiteaDialect
+}
+
+// HasSections tells whether lines such as [Section] are section headers rather than rules
+func (d Dialect) HasSections() bool {
+	return d == GitlabDialect
+}
+
+// HasReviewerGroups tells whether lines such as @@@group @user are reviewer group definitions rather than rules
+// and owners may be written as @@group:random(2)
+func (d Dialect) HasReviewerGroups() bool {
+	return d == BitbucketDialect
+}
+
+// PlatformDialect returns the dialect of the profile of platform, GithubDialect for unknown platforms
+func PlatformDialect(platform string) Dialect {
+	profile, err := PlatformProfile(platform)
+	if err != nil {
+		return GithubDialect
+	}
+	return profile.Dialect
+}
+
+// Pattern is the compiled file pattern of a rule
+type Pattern struct {
+	expr    *regexp.Regexp
+	negated bool
+}
+
+// CompilePattern compiles a file pattern following the syntax of dialect
+func CompilePattern(pattern string, dialect Dialect) (*Pattern, error) {
+	if dialect == GiteaDialect {
+		return compileRegexPattern(pattern)
+	}
+	expr, err := compilePattern(pattern)
+	if err != nil {
+		return nil, err
+	}
+	return &Pattern{expr: expr}, nil
+}
+
+// compileRegexPattern compiles a Gitea pattern, a regular expression matching whole paths the same way Gitea
+// anchors it, a leading '!' matches the paths the expression does not match
+func compileRegexPattern(pattern string) (*Pattern, error) {
+	negated := strings.HasPrefix(pattern, "!")
+	expr, err := regexp.Compile("^" + strings.TrimPrefix(pattern, "!") + "$")
+	if err != nil {
+		return nil, err
+	}
+	return &Pattern{expr: expr, negated: negated}, nil
+}
+
+// Matches returns true if the given path is matched by this pattern
+func (p *Pattern) Matches(path string) bool {
+	return p.expr.MatchString(normalisePath(path)) != p.negated
+}
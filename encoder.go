@@ -0,0 +1,64 @@
+package codeowners
+
+import (
+	"io"
+	"unicode/utf8"
+)
+
+// Encoder provides functionality to write CODEOWNERS data
+type Encoder struct {
+	w io.Writer
+}
+
+// NewEncoder generates a new Encoder instance writing to the given writer
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{
+		w: w,
+	}
+}
+
+// Encode writes every node of the file. Nodes that were not changed since Parse are written back byte for byte,
+// nodes whose kind, pattern, owners or comment were changed, or that were added, are written from those fields
+// with a single space between them.
+func (e *Encoder) Encode(f *File) error {
+	for i, node := range f.Nodes {
+		line := node.Raw
+		if modified(node, f.dialect) {
+			line = encodeNode(node)
+		}
+		eol := node.EOL
+		if len(eol) == 0 && i < len(f.Nodes)-1 {
+			eol = "\n" // nodes added in the middle of the file have no line terminator
+		}
+		if _, err := io.WriteString(e.w, line+eol); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// modified tells whether the fields of node no longer match its raw line
+func modified(node Node, dialect Dialect) bool {
+	parsed := ParseDialectNode(node.Span.Line, node.Raw, dialect)
+	if parsed.Kind != node.Kind || parsed.Pattern.Value != node.Pattern.Value || parsed.Comment.Value != node.Comment.Value ||
+		len(parsed.Owners) != len(node.Owners) {
+		return true
+	}
+	for i, owner := range parsed.Owners {
+		if owner.Value != node.Owners[i].Value {
+			return true
+		}
+	}
+	return false
+}
+
+// encodeNode writes node from its fields
+func encodeNode(node Node) string {
+	switch node.Kind {
+	case BlankNode:
+		return ""
+	case CommentNode:
+		return node.Comment.Value
+	}
+	return formatRule(node, utf8.RuneCountInString(node.Pattern.Value))
+}
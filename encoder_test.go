@@ -0,0 +1,80 @@
+package codeowners_test
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/fmenezes/codeowners"
+)
+
+func TestEncoderRoundTrip(t *testing.T) {
+	testCases := []string{
+		"",
+		"* @owner",
+		"* @owner\n",
+		"  *   @owner   # comment  \r\n\r\n# comment\n\n\n docs/\t@writers",
+		"file\\ with\\ spaces @owner\n\n",
+	}
+
+	for _, input := range testCases {
+		file, err := codeowners.Parse(strings.NewReader(input))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var output bytes.Buffer
+		err = codeowners.NewEncoder(&output).Encode(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if output.String() != input {
+			t.Errorf("Want: %q, Got: %q", input, output.String())
+		}
+	}
+}
+
+func TestEncoderModifiedNodes(t *testing.T) {
+	file, err := codeowners.Parse(strings.NewReader("# comment\n*   @owner\ndocs/  @writers # docs\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	file.Nodes[1].Owners = append(file.Nodes[1].Owners, codeowners.Field{Value: "@reviewer"})
+	file.Nodes[2].Pattern.Value = "/docs/"
+	file.Nodes = append(file.Nodes, codeowners.Node{Kind: codeowners.RuleNode, Pattern: codeowners.Field{Value: "*.go"}, Owners: []codeowners.Field{{Value: "@gophers"}}})
+
+	var output bytes.Buffer
+	err = codeowners.NewEncoder(&output).Encode(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "# comment\n* @owner @reviewer\n/docs/ @writers # docs\n*.go @gophers"
+	if output.String() != want {
+		t.Errorf("Want: %q, Got: %q", want, output.String())
+	}
+
+	reparsed, err := codeowners.Parse(strings.NewReader(output.String()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := len(reparsed.Nodes[1].Owners); got != 2 || reparsed.Nodes[2].Pattern.Value != "/docs/" {
+		t.Errorf("Want: edits to round-trip, Got: %v", reparsed.Nodes)
+	}
+}
+
+func ExampleEncoder() {
+	file, err := codeowners.Parse(strings.NewReader("# comment\n*   @owner\n"))
+	if err != nil {
+		panic(err)
+	}
+	err = codeowners.NewEncoder(os.Stdout).Encode(file)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println("---")
+	// Output:
+	// # comment
+	// *   @owner
+	// ---
+}
@@ -0,0 +1,140 @@
+package codeowners
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+)
+
+// ReplaceSpan returns an edit replacing the text located by the position
+func ReplaceSpan(position Position, newText string) TextEdit {
+	return TextEdit{
+		Position: position,
+		NewText:  newText,
+	}
+}
+
+// DeleteLine returns an edit removing the whole line, including its line terminator
+func DeleteLine(filePath string, lineNo int) TextEdit {
+	return TextEdit{
+		Position: Position{
+			FilePath:    filePath,
+			StartLine:   lineNo,
+			StartColumn: 1,
+			EndLine:     lineNo + 1,
+			EndColumn:   1,
+		},
+	}
+}
+
+// InsertLine returns an edit inserting a new line before the given line number
+func InsertLine(filePath string, lineNo int, text string) TextEdit {
+	return TextEdit{
+		Position: Position{
+			FilePath:    filePath,
+			StartLine:   lineNo,
+			StartColumn: 1,
+			EndLine:     lineNo,
+			EndColumn:   1,
+		},
+		NewText: text + "\n",
+	}
+}
+
+type offsetEdit struct {
+	start   int
+	end     int
+	newText string
+}
+
+func (e offsetEdit) overlaps(other offsetEdit) bool {
+	if e.start == e.end && other.start == other.end {
+		return e.start == other.start // two insertions at the same place have no defined order
+	}
+	return e.start < other.end && other.start < e.end
+}
+
+// ApplyFixes applies the suggested fixes of the given results to the file content.
+// Fixes are considered in order, a fix with an edit overlapping an edit of an already accepted fix is not applied
+// and its result is returned as a conflict. Results without fixes are ignored.
+func ApplyFixes(content []byte, results []CheckResult) ([]byte, []CheckResult, error) {
+	lineOffsets := []int{0}
+	for i, b := range content {
+		if b == '\n' {
+			lineOffsets = append(lineOffsets, i+1)
+		}
+	}
+	offset := func(line, column int) (int, error) {
+		if line == len(lineOffsets)+1 && column == 1 {
+			return len(content), nil
+		}
+		if line < 1 || line > len(lineOffsets) || column < 1 {
+			return 0, fmt.Errorf("Position %d:%d out of range", line, column)
+		}
+		o := lineOffsets[line-1] + column - 1
+		if o > len(content) {
+			return 0, fmt.Errorf("Position %d:%d out of range", line, column)
+		}
+		return o, nil
+	}
+
+	accepted := []offsetEdit{}
+	conflicts := []CheckResult{}
+	for _, result := range results {
+		if result.SuggestedFix == nil {
+			continue
+		}
+
+		edits := []offsetEdit{}
+		for _, edit := range result.SuggestedFix.Edits {
+			start, err := offset(edit.Position.StartLine, edit.Position.StartColumn)
+			if err != nil {
+				return nil, nil, err
+			}
+			end, err := offset(edit.Position.EndLine, edit.Position.EndColumn)
+			if err != nil {
+				return nil, nil, err
+			}
+			if end < start {
+				return nil, nil, fmt.Errorf("Position %s ends before it starts", edit.Position.Format())
+			}
+			edits = append(edits, offsetEdit{start: start, end: end, newText: edit.NewText})
+		}
+
+		conflict := false
+		for _, edit := range edits {
+			for _, other := range accepted {
+				if edit.overlaps(other) {
+					conflict = true
+				}
+			}
+		}
+		if conflict {
+			conflicts = append(conflicts, result)
+			continue
+		}
+		accepted = append(accepted, edits...)
+	}
+
+	// insertions go before a replacement starting at the same offset, so the replacement never starts before the text written so far
+	sort.SliceStable(accepted, func(i, j int) bool {
+		if accepted[i].start != accepted[j].start {
+			return accepted[i].start < accepted[j].start
+		}
+		return accepted[i].start == accepted[i].end && accepted[j].start != accepted[j].end
+	})
+
+	var output bytes.Buffer
+	last := 0
+	for _, edit := range accepted {
+		output.Write(content[last:edit.start])
+		output.WriteString(edit.newText)
+		last = edit.end
+	}
+	output.Write(content[last:])
+
+	if len(conflicts) == 0 {
+		conflicts = nil
+	}
+	return output.Bytes(), conflicts, nil
+}
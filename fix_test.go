@@ -0,0 +1,128 @@
+package codeowners_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/fmenezes/codeowners"
+)
+
+func fixResult(checkName string, edits ...codeowners.TextEdit) codeowners.CheckResult {
+	return codeowners.CheckResult{
+		CheckName:    checkName,
+		SuggestedFix: &codeowners.SuggestedFix{Edits: edits},
+	}
+}
+
+func TestApplyFixes(t *testing.T) {
+	span := func(line, start, end int) codeowners.Position {
+		return codeowners.Position{FilePath: "CODEOWNERS", StartLine: line, StartColumn: start, EndLine: line, EndColumn: end}
+	}
+
+	testCases := []struct {
+		name          string
+		input         string
+		results       []codeowners.CheckResult
+		want          string
+		wantConflicts []string
+	}{
+		{
+			name:    "replace span",
+			input:   "* owner\n",
+			results: []codeowners.CheckResult{fixResult("a", codeowners.ReplaceSpan(span(1, 3, 8), "@owner"))},
+			want:    "* @owner\n",
+		},
+		{
+			name:    "delete line",
+			input:   "* @a\n* @b\n* @c\n",
+			results: []codeowners.CheckResult{fixResult("a", codeowners.DeleteLine("CODEOWNERS", 2))},
+			want:    "* @a\n* @c\n",
+		},
+		{
+			name:    "delete last line without line feed",
+			input:   "* @a\n* @b",
+			results: []codeowners.CheckResult{fixResult("a", codeowners.DeleteLine("CODEOWNERS", 2))},
+			want:    "* @a\n",
+		},
+		{
+			name:    "insert line",
+			input:   "* @a\n",
+			results: []codeowners.CheckResult{fixResult("a", codeowners.InsertLine("CODEOWNERS", 1, "# header"))},
+			want:    "# header\n* @a\n",
+		},
+		{
+			name:  "multiple fixes",
+			input: "a b\nc d\n",
+			results: []codeowners.CheckResult{
+				{CheckName: "no fix"},
+				fixResult("a", codeowners.ReplaceSpan(span(2, 3, 4), "@d")),
+				fixResult("b", codeowners.ReplaceSpan(span(1, 3, 4), "@b")),
+			},
+			want: "a @b\nc @d\n",
+		},
+		{
+			name:  "conflicting fixes",
+			input: "a b c\n",
+			results: []codeowners.CheckResult{
+				fixResult("a", codeowners.ReplaceSpan(span(1, 1, 4), "x")),
+				fixResult("b", codeowners.ReplaceSpan(span(1, 3, 6), "y"), codeowners.ReplaceSpan(span(1, 1, 1), "z")),
+				fixResult("c", codeowners.DeleteLine("CODEOWNERS", 1)),
+			},
+			want:          "x c\n",
+			wantConflicts: []string{"b", "c"},
+		},
+		{
+			name:  "conflicting insertions",
+			input: "a\n",
+			results: []codeowners.CheckResult{
+				fixResult("a", codeowners.InsertLine("CODEOWNERS", 1, "x")),
+				fixResult("b", codeowners.InsertLine("CODEOWNERS", 1, "y")),
+			},
+			want:          "x\na\n",
+			wantConflicts: []string{"b"},
+		},
+		{
+			name:  "insertion at the start of a replacement",
+			input: "a @x\nb @y\n",
+			results: []codeowners.CheckResult{
+				fixResult("a", codeowners.DeleteLine("CODEOWNERS", 1)),
+				fixResult("b", codeowners.InsertLine("CODEOWNERS", 1, "# header")),
+			},
+			want: "# header\nb @y\n",
+		},
+		{
+			name:  "insertion at the end of a replacement",
+			input: "a @x\nb @y\n",
+			results: []codeowners.CheckResult{
+				fixResult("a", codeowners.DeleteLine("CODEOWNERS", 1)),
+				fixResult("b", codeowners.InsertLine("CODEOWNERS", 2, "# header")),
+			},
+			want: "# header\nb @y\n",
+		},
+	}
+
+	for _, testCase := range testCases {
+		got, conflicts, err := codeowners.ApplyFixes([]byte(testCase.input), testCase.results)
+		if err != nil {
+			t.Errorf("%s: %v", testCase.name, err)
+			continue
+		}
+		var gotConflicts []string
+		for _, conflict := range conflicts {
+			gotConflicts = append(gotConflicts, conflict.CheckName)
+		}
+		if string(got) != testCase.want || !reflect.DeepEqual(gotConflicts, testCase.wantConflicts) {
+			t.Errorf("%s: Want: %q %v, Got: %q %v", testCase.name, testCase.want, testCase.wantConflicts, string(got), gotConflicts)
+		}
+	}
+}
+
+func TestApplyFixesOutOfRange(t *testing.T) {
+	results := []codeowners.CheckResult{
+		fixResult("a", codeowners.DeleteLine("CODEOWNERS", 5)),
+	}
+	_, _, err := codeowners.ApplyFixes([]byte("* @a\n"), results)
+	if err == nil {
+		t.Error("Should have errored")
+	}
+}
@@ -0,0 +1,84 @@
+package codeowners
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// Format returns a canonical version of the file.
+// Surrounding white space is removed, owners are separated by a single space and aligned
+// within blocks of rules separated by blank lines, consecutive blank lines are collapsed
+// and every line is terminated by a single line feed. Comments are preserved.
+// Section headers and reviewer group definitions are kept on their own, followed by their owners, and start a new block.
+func Format(f *File) *File {
+	var lines []string
+	blank := true // drops blank lines at the beginning of the file
+	width := 0
+	for i, node := range f.Nodes {
+		switch node.Kind {
+		case BlankNode:
+			if !blank {
+				lines = append(lines, "")
+			}
+			blank = true
+			width = 0
+			continue
+		case CommentNode:
+			lines = append(lines, strings.TrimSpace(node.Comment.Value))
+		case RuleNode:
+			if blank || width == 0 {
+				width = blockWidth(f.Nodes[i:])
+			}
+			lines = append(lines, formatRule(node, width))
+		case SectionNode, GroupNode:
+			lines = append(lines, formatRule(node, utf8.RuneCountInString(node.Pattern.Value)))
+			width = 0
+		}
+		blank = false
+	}
+	if blank && len(lines) > 0 {
+		lines = lines[:len(lines)-1]
+	}
+
+	if len(lines) == 0 {
+		return parse("", f.dialect)
+	}
+	return parse(strings.Join(lines, "\n")+"\n", f.dialect)
+}
+
+// blockWidth computes the widest pattern followed by owners until the next blank line, section header or group definition
+func blockWidth(nodes []Node) int {
+	width := 0
+	for _, node := range nodes {
+		if node.Kind == BlankNode || node.Kind == SectionNode || node.Kind == GroupNode {
+			break
+		}
+		if node.Kind != RuleNode || len(node.Owners) == 0 {
+			continue
+		}
+		if w := utf8.RuneCountInString(node.Pattern.Value); w > width {
+			width = w
+		}
+	}
+	return width
+}
+
+// formatRule writes the rule in its canonical form with owners starting after a pattern column of the given width
+func formatRule(node Node, width int) string {
+	var line strings.Builder
+	line.WriteString(node.Pattern.Value)
+	if len(node.Owners) > 0 {
+		line.WriteString(strings.Repeat(" ", width-utf8.RuneCountInString(node.Pattern.Value)+1))
+		for i, owner := range node.Owners {
+			if i > 0 {
+				line.WriteString(" ")
+			}
+			line.WriteString(owner.Value)
+		}
+	}
+	if len(node.Comment.Value) > 0 {
+		line.WriteString(" ")
+		line.WriteString(strings.TrimRight(node.Comment.Value, " \t"))
+	}
+	return line.String()
+}
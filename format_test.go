@@ -0,0 +1,91 @@
+package codeowners_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/fmenezes/codeowners"
+)
+
+func format(t *testing.T, input string) string {
+	file, err := codeowners.Parse(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var output bytes.Buffer
+	err = codeowners.NewEncoder(&output).Encode(codeowners.Format(file))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return output.String()
+}
+
+func TestFormat(t *testing.T) {
+	testCases := []struct {
+		input string
+		want  string
+	}{
+		{
+			input: "",
+			want:  "",
+		},
+		{
+			input: "\n\n",
+			want:  "",
+		},
+		{
+			input: "* @owner",
+			want:  "* @owner\n",
+		},
+		{
+			input: "  *\t\t@owner    @other   #   comment   \r\n",
+			want:  "* @owner @other #   comment\n",
+		},
+		{
+			input: "* @default\n/very/long/path @long\n  # comment\ndocs/ @writers\n\n\n\nlib @lib\n\n",
+			want:  "*               @default\n/very/long/path @long\n# comment\ndocs/           @writers\n\nlib @lib\n",
+		},
+		{
+			input: "/very/long/path/without/owners\n* @owner\n",
+			want:  "/very/long/path/without/owners\n* @owner\n",
+		},
+		{
+			input: "\n# header\n\nfile\\ with\\ spaces @owner\n",
+			want:  "# header\n\nfile\\ with\\ spaces @owner\n",
+		},
+	}
+
+	for _, testCase := range testCases {
+		got := format(t, testCase.input)
+		if got != testCase.want {
+			t.Errorf("Input: %q, Want: %q, Got: %q", testCase.input, testCase.want, got)
+		}
+	}
+}
+
+func TestFormatIdempotent(t *testing.T) {
+	input := "* @default\n/very/long/path @long # comment\n\n  # comment\ndocs/ @writers\n"
+	once := format(t, input)
+	twice := format(t, once)
+	if once != twice {
+		t.Errorf("Want: %q, Got: %q", once, twice)
+	}
+}
+
+func TestFormatSections(t *testing.T) {
+	input := "* @default\n  [Documentation]   @docs-team   # docs\ndocs/   @writers\n/very/long/path @long\n^[Backend][2] @backend\n"
+	want := "* @default\n[Documentation] @docs-team # docs\ndocs/           @writers\n/very/long/path @long\n^[Backend][2] @backend\n"
+	file, err := codeowners.ParseDialect(strings.NewReader(input), codeowners.GitlabDialect)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var output bytes.Buffer
+	err = codeowners.NewEncoder(&output).Encode(codeowners.Format(file))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := output.String(); got != want {
+		t.Errorf("Input: %q, Want: %q, Got: %q", input, want, got)
+	}
+}
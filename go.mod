@@ -5,4 +5,5 @@ go 1.14
 require (
 	github.com/google/go-github/v32 v32.1.0
 	golang.org/x/oauth2 v0.0.0-20180821212333-d2e6202438be
+	gopkg.in/yaml.v2 v2.4.0
 )
@@ -0,0 +1,58 @@
+package codeowners
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Reviewer selection strategies of Bitbucket groups
+const (
+	RandomStrategy    string = "random"     // RandomStrategy adds the given number of members of the group picked at random as reviewers
+	LeastBusyStrategy string = "least_busy" // LeastBusyStrategy adds the given number of members of the group with the fewest open reviews as reviewers
+)
+
+// Owner is an owner of a rule split into its parts
+type Owner struct {
+	Name      string // Name is the owner without its selection strategy, such as @user, @org/team, @@group or an email
+	Group     bool   // Group is true for Bitbucket groups written as @@group
+	Strategy  string // Strategy is the reviewer selection strategy of a group, empty when every member is a reviewer
+	Reviewers int    // Reviewers is the number of members the strategy selects, zero without a strategy
+}
+
+// ParseOwner splits owner into its parts following dialect, only BitbucketDialect has groups and selection strategies
+// such as @@group:random(2), an error tells why a strategy is malformed
+func ParseOwner(owner string, dialect Dialect) (Owner, error) {
+	if !dialect.HasReviewerGroups() {
+		return Owner{Name: owner}, nil
+	}
+
+	parsed := Owner{Name: owner}
+	strategy := ""
+	if i := strings.LastIndex(owner, ":"); i >= 0 {
+		parsed.Name, strategy = owner[:i], owner[i+1:]
+	}
+	parsed.Group = strings.HasPrefix(parsed.Name, "@@") && !strings.HasPrefix(parsed.Name, "@@@")
+	if len(strategy) == 0 && parsed.Name == owner {
+		return parsed, nil
+	}
+
+	open := strings.Index(strategy, "(")
+	if open < 0 || !strings.HasSuffix(strategy, ")") {
+		return parsed, fmt.Errorf("malformed selection strategy '%s', expected random(N) or least_busy(N)", strategy)
+	}
+	parsed.Strategy = strategy[:open]
+	if parsed.Strategy != RandomStrategy && parsed.Strategy != LeastBusyStrategy {
+		return parsed, fmt.Errorf("unknown selection strategy '%s', expected random or least_busy", parsed.Strategy)
+	}
+	count := strategy[open+1 : len(strategy)-1]
+	reviewers, err := strconv.Atoi(count)
+	if err != nil || reviewers < 1 {
+		return parsed, fmt.Errorf("invalid number of reviewers '%s'", count)
+	}
+	parsed.Reviewers = reviewers
+	if !parsed.Group {
+		return parsed, fmt.Errorf("selection strategies only apply to @@groups")
+	}
+	return parsed, nil
+}
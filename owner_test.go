@@ -0,0 +1,39 @@
+package codeowners_test
+
+import (
+	"testing"
+
+	"github.com/fmenezes/codeowners"
+)
+
+func TestParseOwner(t *testing.T) {
+	testCases := []struct {
+		owner   string
+		dialect codeowners.Dialect
+		want    codeowners.Owner
+		wantErr string
+	}{
+		{owner: "@owner", dialect: codeowners.GithubDialect, want: codeowners.Owner{Name: "@owner"}},
+		{owner: "@@group:random(2)", dialect: codeowners.GithubDialect, want: codeowners.Owner{Name: "@@group:random(2)"}},
+		{owner: "@user", dialect: codeowners.BitbucketDialect, want: codeowners.Owner{Name: "@user"}},
+		{owner: "@@group", dialect: codeowners.BitbucketDialect, want: codeowners.Owner{Name: "@@group", Group: true}},
+		{owner: "@@group:random(2)", dialect: codeowners.BitbucketDialect, want: codeowners.Owner{Name: "@@group", Group: true, Strategy: "random", Reviewers: 2}},
+		{owner: "@@group:least_busy(1)", dialect: codeowners.BitbucketDialect, want: codeowners.Owner{Name: "@@group", Group: true, Strategy: "least_busy", Reviewers: 1}},
+		{owner: "@@group:random", dialect: codeowners.BitbucketDialect, wantErr: "malformed selection strategy 'random', expected random(N) or least_busy(N)"},
+		{owner: "@@group:all(1)", dialect: codeowners.BitbucketDialect, wantErr: "unknown selection strategy 'all', expected random or least_busy"},
+		{owner: "@@group:random(0)", dialect: codeowners.BitbucketDialect, wantErr: "invalid number of reviewers '0'"},
+		{owner: "@user:random(1)", dialect: codeowners.BitbucketDialect, wantErr: "selection strategies only apply to @@groups"},
+	}
+	for _, testCase := range testCases {
+		got, err := codeowners.ParseOwner(testCase.owner, testCase.dialect)
+		if len(testCase.wantErr) > 0 {
+			if err == nil || err.Error() != testCase.wantErr {
+				t.Errorf("Input: %v, Want: %v, Got: %v", testCase.owner, testCase.wantErr, err)
+			}
+			continue
+		}
+		if err != nil || got != testCase.want {
+			t.Errorf("Input: %v, Want: %v, Got: %v %v", testCase.owner, testCase.want, got, err)
+		}
+	}
+}
@@ -0,0 +1,316 @@
+package codeowners
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// NodeKind exposes all possible kinds of CODEOWNERS lines
+type NodeKind int
+
+// All possible node kinds
+const (
+	RuleNode    NodeKind = iota // RuleNode is a line with a file pattern, optionally followed by owners and a comment
+	CommentNode                 // CommentNode is a line containing only a comment
+	BlankNode                   // BlankNode is a line containing only white space
+	SectionNode                 // SectionNode is a section header such as ^[Section][2] @owner, only found in dialects with sections
+	GroupNode                   // GroupNode is a reviewer group definition such as @@@group @user, only found in dialects with reviewer groups
+)
+
+// Name returns the string representation of this node kind
+func (k NodeKind) Name() string {
+	return [...]string{"Rule", "Comment", "Blank", "Section", "Group"}[k]
+}
+
+// Span locates a piece of text inside a CODEOWNERS file
+type Span struct {
+	Offset      int // Offset is the byte offset from the beginning of the file
+	Line        int // Line is the 1-based line number
+	StartColumn int // StartColumn is the 1-based byte column where the text starts
+	EndColumn   int // EndColumn is the 1-based byte column right after the text ends
+}
+
+// Position converts the span into a Position within the given file
+func (s Span) Position(filePath string) Position {
+	return Position{
+		FilePath:    filePath,
+		StartLine:   s.Line,
+		StartColumn: s.StartColumn,
+		EndLine:     s.Line,
+		EndColumn:   s.EndColumn,
+	}
+}
+
+// Field is a piece of text along with its location
+type Field struct {
+	Value string
+	Span  Span
+}
+
+// Section is a GitLab section, the rules following its header belong to it until the next header
+type Section struct {
+	Name      Field   // Name is the section name without brackets, sections sharing a name case insensitively are the same section
+	Optional  bool    // Optional is true for headers starting with '^', approval from the section owners is then optional
+	Approvals int     // Approvals is the number of approvals required from the section owners, 1 unless given as [Section][N]
+	Owners    []Field // Owners are the default owners, they own the rules of the section declared without owners
+	Invalid   string  // Invalid explains why the header is malformed, empty for valid headers
+}
+
+// Node represents a single line of a CODEOWNERS file
+type Node struct {
+	Kind    NodeKind
+	Raw     string   // Raw is the line as it was read, without the line terminator
+	EOL     string   // EOL is the line terminator, empty for a last line without one
+	Span    Span     // Span locates the whole line, without the line terminator
+	Pattern Field    // Pattern is the file pattern, the whole header of a SectionNode or the @@@group of a GroupNode, empty for other kinds
+	Owners  []Field  // Owners are the owners following the pattern, the default owners following a header or the members of a group
+	Comment Field    // Comment is the comment including the leading '#', empty if there is none
+	Section *Section // Section is the header of a SectionNode, or the section a RuleNode of a file belongs to, nil otherwise
+}
+
+// File represents a parsed CODEOWNERS file, every line is kept as a node
+type File struct {
+	Nodes   []Node
+	dialect Dialect
+}
+
+// Rules returns only the nodes containing rules
+func (f *File) Rules() []Node {
+	var rules []Node
+	for _, node := range f.Nodes {
+		if node.Kind == RuleNode {
+			rules = append(rules, node)
+		}
+	}
+	return rules
+}
+
+// Parse reads the whole CODEOWNERS file and returns every line as a node following GithubDialect
+func Parse(r io.Reader) (*File, error) {
+	return ParseDialect(r, GithubDialect)
+}
+
+// ParseDialect reads the whole CODEOWNERS file and returns every line as a node following dialect,
+// in dialects with sections every rule is linked to the section it belongs to
+func ParseDialect(r io.Reader, dialect Dialect) (*File, error) {
+	content, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return parse(string(content), dialect), nil
+}
+
+func parse(data string, dialect Dialect) *File {
+	file := &File{dialect: dialect}
+	var section *Section
+	offset := 0
+	lineNo := 0
+	for offset < len(data) {
+		lineNo++
+		line := data[offset:]
+		eol := ""
+		if i := strings.IndexByte(line, '\n'); i >= 0 {
+			line = line[:i]
+			eol = "\n"
+			if strings.HasSuffix(line, "\r") {
+				line = line[:len(line)-1]
+				eol = "\r\n"
+			}
+		}
+		node := parseNode(lineNo, offset, line, dialect)
+		node.EOL = eol
+		switch node.Kind {
+		case SectionNode:
+			section = node.Section
+		case RuleNode:
+			node.Section = section
+		}
+		file.Nodes = append(file.Nodes, node)
+		offset += len(line) + len(eol)
+	}
+
+	return file
+}
+
+// ParseNode parses a single CODEOWNERS line into a node following GithubDialect, spans are relative to the start of the line
+func ParseNode(lineNo int, line string) Node {
+	return parseNode(lineNo, 0, line, GithubDialect)
+}
+
+// ParseDialectNode parses a single CODEOWNERS line into a node following dialect, spans are relative to the start of the line.
+// Rules are not linked to their section, as it is only known when parsing the whole file.
+func ParseDialectNode(lineNo int, line string, dialect Dialect) Node {
+	return parseNode(lineNo, 0, line, dialect)
+}
+
+func parseNode(lineNo int, offset int, line string, dialect Dialect) Node {
+	span := func(start, end int) Span {
+		return Span{
+			Offset:      offset + start,
+			Line:        lineNo,
+			StartColumn: start + 1,
+			EndColumn:   end + 1,
+		}
+	}
+
+	node := Node{
+		Raw:  line,
+		Span: span(0, len(line)),
+	}
+
+	content := line
+	if i := commentStart(line); i >= 0 {
+		content = line[:i]
+		node.Comment = Field{Value: line[i:], Span: span(i, len(line))}
+	}
+
+	fields := splitFields(content)
+	switch {
+	case len(fields) > 0:
+		node.Kind = RuleNode
+	case len(node.Comment.Value) > 0:
+		node.Kind = CommentNode
+		return node
+	default:
+		node.Kind = BlankNode
+		return node
+	}
+
+	if dialect.HasSections() && isSectionHeader(content[fields[0][0]:]) {
+		node.Kind = SectionNode
+		node.Section = parseSection(&node, content, fields[0][0], span)
+		return node
+	}
+	if dialect.HasReviewerGroups() && strings.HasPrefix(content[fields[0][0]:], "@@@") {
+		node.Kind = GroupNode
+	}
+
+	for i, field := range fields {
+		f := Field{Value: content[field[0]:field[1]], Span: span(field[0], field[1])}
+		if i == 0 {
+			node.Pattern = f
+		} else {
+			node.Owners = append(node.Owners, f)
+		}
+	}
+
+	return node
+}
+
+// isSectionHeader tells whether content, starting at its first field, is a section header rather than a rule,
+// paths starting with a bracket are written escaped as \[
+func isSectionHeader(content string) bool {
+	return strings.HasPrefix(content, "[") || strings.HasPrefix(content, "^[")
+}
+
+// parseSection parses the section header starting at start, filling the pattern and default owners of node.
+// Malformed headers are still returned as sections, along with the reason they are invalid.
+func parseSection(node *Node, content string, start int, span func(start, end int) Span) *Section {
+	section := &Section{Approvals: 1}
+	end := len(strings.TrimRightFunc(content, unicode.IsSpace))
+	header := func(end int) {
+		node.Pattern = Field{Value: content[start:end], Span: span(start, end)}
+	}
+
+	i := start
+	if content[i] == '^' {
+		section.Optional = true
+		i++
+	}
+	closing := strings.IndexByte(content[i:], ']')
+	if closing < 0 {
+		section.Name = Field{Value: content[i+1 : end], Span: span(i+1, end)}
+		section.Invalid = "missing closing bracket"
+		header(end)
+		return section
+	}
+	closing += i
+	section.Name = Field{Value: content[i+1 : closing], Span: span(i+1, closing)}
+	if len(strings.TrimSpace(section.Name.Value)) == 0 {
+		section.Invalid = "empty section name"
+	}
+	i = closing + 1
+
+	if i < len(content) && content[i] == '[' {
+		closing = strings.IndexByte(content[i:], ']')
+		if closing < 0 {
+			section.Invalid = "missing closing bracket of the approval count"
+			header(end)
+			return section
+		}
+		closing += i
+		count := content[i+1 : closing]
+		approvals, err := strconv.Atoi(count)
+		if err != nil || approvals < 1 {
+			section.Invalid = fmt.Sprintf("invalid approval count '%s'", count)
+		} else {
+			section.Approvals = approvals
+		}
+		i = closing + 1
+	}
+
+	if i < len(content) && !unicode.IsSpace(rune(content[i])) {
+		next := strings.IndexFunc(content[i:], unicode.IsSpace)
+		if next < 0 {
+			next = len(content) - i
+		}
+		if len(section.Invalid) == 0 {
+			section.Invalid = fmt.Sprintf("unexpected '%s' after the header", content[i:i+next])
+		}
+		i += next
+	}
+	header(i)
+
+	for _, field := range splitFields(content[i:]) {
+		owner := Field{Value: content[i+field[0] : i+field[1]], Span: span(i+field[0], i+field[1])}
+		node.Owners = append(node.Owners, owner)
+	}
+	section.Owners = node.Owners
+	return section
+}
+
+// commentStart returns the byte offset of the first # not escaped by a backslash, -1 when the line has no comment
+func commentStart(line string) int {
+	escaped := false
+	for i, r := range line {
+		switch {
+		case escaped:
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '#':
+			return i
+		}
+	}
+	return -1
+}
+
+// splitFields splits the content on white space not escaped by a backslash, returning the byte ranges of each field
+func splitFields(content string) [][2]int {
+	var fields [][2]int
+	var previousRune rune
+	start := -1
+	for i, r := range content {
+		separator := unicode.IsSpace(r) && previousRune != '\\'
+		previousRune = r
+		if separator {
+			if start >= 0 {
+				fields = append(fields, [2]int{start, i})
+				start = -1
+			}
+			continue
+		}
+		if start < 0 {
+			start = i
+		}
+	}
+	if start >= 0 {
+		fields = append(fields, [2]int{start, len(content)})
+	}
+	return fields
+}
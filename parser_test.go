@@ -0,0 +1,205 @@
+package codeowners_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/fmenezes/codeowners"
+)
+
+func TestParse(t *testing.T) {
+	input := "* @owner # comment\r\n\n# only comment\ndocs/ docs @company/team"
+	want := &codeowners.File{
+		Nodes: []codeowners.Node{
+			{
+				Kind: codeowners.RuleNode,
+				Raw:  "* @owner # comment",
+				EOL:  "\r\n",
+				Span: codeowners.Span{Offset: 0, Line: 1, StartColumn: 1, EndColumn: 19},
+				Pattern: codeowners.Field{
+					Value: "*",
+					Span:  codeowners.Span{Offset: 0, Line: 1, StartColumn: 1, EndColumn: 2},
+				},
+				Owners: []codeowners.Field{
+					{
+						Value: "@owner",
+						Span:  codeowners.Span{Offset: 2, Line: 1, StartColumn: 3, EndColumn: 9},
+					},
+				},
+				Comment: codeowners.Field{
+					Value: "# comment",
+					Span:  codeowners.Span{Offset: 9, Line: 1, StartColumn: 10, EndColumn: 19},
+				},
+			},
+			{
+				Kind: codeowners.BlankNode,
+				Raw:  "",
+				EOL:  "\n",
+				Span: codeowners.Span{Offset: 20, Line: 2, StartColumn: 1, EndColumn: 1},
+			},
+			{
+				Kind: codeowners.CommentNode,
+				Raw:  "# only comment",
+				EOL:  "\n",
+				Span: codeowners.Span{Offset: 21, Line: 3, StartColumn: 1, EndColumn: 15},
+				Comment: codeowners.Field{
+					Value: "# only comment",
+					Span:  codeowners.Span{Offset: 21, Line: 3, StartColumn: 1, EndColumn: 15},
+				},
+			},
+			{
+				Kind: codeowners.RuleNode,
+				Raw:  "docs/ docs @company/team",
+				EOL:  "",
+				Span: codeowners.Span{Offset: 36, Line: 4, StartColumn: 1, EndColumn: 25},
+				Pattern: codeowners.Field{
+					Value: "docs/",
+					Span:  codeowners.Span{Offset: 36, Line: 4, StartColumn: 1, EndColumn: 6},
+				},
+				Owners: []codeowners.Field{
+					{
+						Value: "docs",
+						Span:  codeowners.Span{Offset: 42, Line: 4, StartColumn: 7, EndColumn: 11},
+					},
+					{
+						Value: "@company/team",
+						Span:  codeowners.Span{Offset: 47, Line: 4, StartColumn: 12, EndColumn: 25},
+					},
+				},
+			},
+		},
+	}
+
+	got, err := codeowners.Parse(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Input: %q, Want: %+v, Got: %+v", input, want, got)
+	}
+	if len(got.Rules()) != 2 {
+		t.Errorf("Input: %q, Want: 2 rules, Got: %d", input, len(got.Rules()))
+	}
+}
+
+func TestParseEmptyFile(t *testing.T) {
+	got, err := codeowners.Parse(strings.NewReader(""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Nodes) != 0 {
+		t.Errorf("Want no nodes, Got: %v", got.Nodes)
+	}
+}
+
+func TestParseNodeEscapedSpaces(t *testing.T) {
+	got := codeowners.ParseNode(3, `  file\ with\ spaces @owner`)
+	want := codeowners.Field{
+		Value: `file\ with\ spaces`,
+		Span:  codeowners.Span{Offset: 2, Line: 3, StartColumn: 3, EndColumn: 21},
+	}
+	if got.Kind != codeowners.RuleNode || !reflect.DeepEqual(got.Pattern, want) {
+		t.Errorf("Want: %v, Got: %v", want, got.Pattern)
+	}
+}
+
+func TestParseNodeEscapedHash(t *testing.T) {
+	tests := []struct {
+		line        string
+		wantPattern string
+		wantOwners  int
+		wantComment string
+	}{
+		{line: `\#file @a`, wantPattern: `\#file`, wantOwners: 1},
+		{line: `\#file @a # comment`, wantPattern: `\#file`, wantOwners: 1, wantComment: "# comment"},
+		{line: `file\\#comment`, wantPattern: `file\\`, wantComment: "#comment"},
+	}
+	for _, test := range tests {
+		got := codeowners.ParseNode(1, test.line)
+		if got.Kind != codeowners.RuleNode || got.Pattern.Value != test.wantPattern || len(got.Owners) != test.wantOwners ||
+			got.Comment.Value != test.wantComment {
+			t.Errorf("Input: %v, Want: %v %v %v, Got: %v", test.line, test.wantPattern, test.wantOwners, test.wantComment, got)
+		}
+	}
+}
+
+func TestNodeKindNames(t *testing.T) {
+	if codeowners.RuleNode.Name() != "Rule" || codeowners.CommentNode.Name() != "Comment" || codeowners.BlankNode.Name() != "Blank" || codeowners.SectionNode.Name() != "Section" || codeowners.GroupNode.Name() != "Group" {
+		t.Error("Unexpected node kind names")
+	}
+}
+
+func TestParseSections(t *testing.T) {
+	input := "* @default\n^[Section Name][2] @owner # comment\ndocs/\n[Other]\nsrc/ @gophers\n"
+	file, err := codeowners.ParseDialect(strings.NewReader(input), codeowners.GitlabDialect)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantKinds := []codeowners.NodeKind{codeowners.RuleNode, codeowners.SectionNode, codeowners.RuleNode, codeowners.SectionNode, codeowners.RuleNode}
+	for i, node := range file.Nodes {
+		if node.Kind != wantKinds[i] {
+			t.Errorf("Line: %d, Want: %v, Got: %v", i+1, wantKinds[i].Name(), node.Kind.Name())
+		}
+	}
+
+	header := file.Nodes[1]
+	wantSection := &codeowners.Section{
+		Name:      codeowners.Field{Value: "Section Name", Span: codeowners.Span{Offset: 13, Line: 2, StartColumn: 3, EndColumn: 15}},
+		Optional:  true,
+		Approvals: 2,
+		Owners:    []codeowners.Field{{Value: "@owner", Span: codeowners.Span{Offset: 30, Line: 2, StartColumn: 20, EndColumn: 26}}},
+	}
+	if !reflect.DeepEqual(header.Section, wantSection) {
+		t.Errorf("Want: %v, Got: %v", wantSection, header.Section)
+	}
+	if header.Pattern.Value != "^[Section Name][2]" || header.Comment.Value != "# comment" {
+		t.Errorf("Want: '^[Section Name][2]' '# comment', Got: '%s' '%s'", header.Pattern.Value, header.Comment.Value)
+	}
+	if file.Nodes[0].Section != nil || file.Nodes[2].Section != header.Section || file.Nodes[4].Section != file.Nodes[3].Section {
+		t.Error("Rules are not linked to their section")
+	}
+}
+
+func TestParseInvalidSections(t *testing.T) {
+	testCases := []struct {
+		line        string
+		wantHeader  string
+		wantInvalid string
+	}{
+		{line: "[Section", wantHeader: "[Section", wantInvalid: "missing closing bracket"},
+		{line: "[] @owner", wantHeader: "[]", wantInvalid: "empty section name"},
+		{line: "[Section][two] @owner", wantHeader: "[Section][two]", wantInvalid: "invalid approval count 'two'"},
+		{line: "[Section][2", wantHeader: "[Section][2", wantInvalid: "missing closing bracket of the approval count"},
+		{line: "[Section]@owner", wantHeader: "[Section]@owner", wantInvalid: "unexpected '@owner' after the header"},
+		{line: "[Section][1] @owner", wantHeader: "[Section][1]", wantInvalid: ""},
+	}
+	for _, testCase := range testCases {
+		node := codeowners.ParseDialectNode(1, testCase.line, codeowners.GitlabDialect)
+		if node.Kind != codeowners.SectionNode || node.Pattern.Value != testCase.wantHeader || node.Section.Invalid != testCase.wantInvalid {
+			t.Errorf("Input: %v, Want: %v, Got: %v", testCase.line, testCase.wantInvalid, node.Section)
+		}
+	}
+}
+
+func TestParseDialectNodeKinds(t *testing.T) {
+	testCases := []struct {
+		line    string
+		dialect codeowners.Dialect
+		want    codeowners.NodeKind
+	}{
+		{line: "[Section] @owner", dialect: codeowners.GithubDialect, want: codeowners.RuleNode},
+		{line: "[Section] @owner", dialect: codeowners.GitlabDialect, want: codeowners.SectionNode},
+		{line: `\[literal].md @owner`, dialect: codeowners.GitlabDialect, want: codeowners.RuleNode},
+		{line: "@@@group @user", dialect: codeowners.GithubDialect, want: codeowners.RuleNode},
+		{line: "@@@group @user", dialect: codeowners.BitbucketDialect, want: codeowners.GroupNode},
+		{line: "[Section] @owner", dialect: codeowners.BitbucketDialect, want: codeowners.RuleNode},
+	}
+	for _, testCase := range testCases {
+		got := codeowners.ParseDialectNode(1, testCase.line, testCase.dialect)
+		if got.Kind != testCase.want {
+			t.Errorf("Input: %v, Want: %v, Got: %v", testCase.line, testCase.want.Name(), got.Kind.Name())
+		}
+	}
+}
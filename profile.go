@@ -0,0 +1,120 @@
+package codeowners
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Profile tells where a platform looks for the CODEOWNERS file, how large the file may be and which syntax it reads
+type Profile struct {
+	Platform  string   // Platform is the platform described, such as GithubPlatform
+	Locations []string // Locations are where the file is looked for, in order of precedence, the first one found is used
+	MaxSize   int64    // MaxSize is the size in bytes above which the platform ignores the file, zero when there is no limit
+	Dialect   Dialect  // Dialect is the syntax the platform reads
+}
+
+// Profiles of every supported platform
+var (
+	GithubProfile = Profile{
+		Platform:  GithubPlatform,
+		Locations: []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS"},
+		MaxSize:   3 * 1024 * 1024,
+		Dialect:   GithubDialect,
+	}
+	GitlabProfile = Profile{
+		Platform:  GitlabPlatform,
+		Locations: []string{"CODEOWNERS", "docs/CODEOWNERS", ".gitlab/CODEOWNERS"},
+		Dialect:   GitlabDialect,
+	}
+	BitbucketProfile = Profile{
+		Platform:  BitbucketPlatform,
+		Locations: []string{".bitbucket/CODEOWNERS"},
+		Dialect:   BitbucketDialect,
+	}
+	GiteaProfile = Profile{
+		Platform:  GiteaPlatform,
+		Locations: []string{"CODEOWNERS", "docs/CODEOWNERS", ".gitea/CODEOWNERS"},
+		Dialect:   GiteaDialect,
+	}
+)
+
+// PlatformProfile returns the profile of platform, GithubProfile when platform is empty
+func PlatformProfile(platform string) (Profile, error) {
+	switch platform {
+	case "", GithubPlatform:
+		return GithubProfile, nil
+	case GitlabPlatform:
+		return GitlabProfile, nil
+	case BitbucketPlatform:
+		return BitbucketProfile, nil
+	case GiteaPlatform:
+		return GiteaProfile, nil
+	}
+	return Profile{}, fmt.Errorf("Unknown platform '%s'", platform)
+}
+
+// codeownersFiles lists the locations of this profile where a CODEOWNERS file exists, in order of precedence
+func (p Profile) codeownersFiles(dir string) []string {
+	filesFound := []string{}
+	for _, fileLocation := range p.Locations {
+		currentFile := filepath.Join(dir, fileLocation)
+		if fileExists(currentFile) {
+			filesFound = append(filesFound, fileLocation)
+		}
+	}
+	return filesFound
+}
+
+// FindCodeownersFile returns the location, relative to the given directory, of the CODEOWNERS file the platform uses
+func (p Profile) FindCodeownersFile(dir string) (string, error) {
+	filesFound := p.codeownersFiles(dir)
+	if len(filesFound) == 0 {
+		return "", errors.New("No CODEOWNERS file found")
+	}
+	return filesFound[0], nil
+}
+
+// findCodeownersFile returns the location of the CODEOWNERS file the platform uses, the highest in precedence, along with
+// a warning for every other file found as the platform ignores them. When there is no file to check the location is empty
+// and the results tell why.
+func (p Profile) findCodeownersFile(dir string) (string, []CheckResult) {
+	filesFound := p.codeownersFiles(dir)
+
+	if len(filesFound) == 0 {
+		return "", []CheckResult{{Position: Position{FilePath: "CODEOWNERS"}, Message: "No CODEOWNERS file found", Severity: Error, CheckName: "NoCodeowners"}}
+	}
+
+	if p.MaxSize > 0 {
+		info, err := os.Stat(filepath.Join(dir, filesFound[0]))
+		if err == nil && info.Size() > p.MaxSize {
+			return "", []CheckResult{{Position: Position{FilePath: filesFound[0]}, Message: fmt.Sprintf("CODEOWNERS file is %d bytes, %s ignores files larger than %d bytes", info.Size(), p.Platform, p.MaxSize), Severity: Error, CheckName: "CodeownersTooLarge"}}
+		}
+	}
+
+	var shadowed []CheckResult
+	for _, fileLocation := range filesFound[1:] {
+		shadowed = append(shadowed, CheckResult{Position: Position{FilePath: fileLocation}, Message: fmt.Sprintf("CODEOWNERS file is shadowed by %s, which %s reads first", filesFound[0], p.Platform), Severity: Warning, CheckName: "MultipleCodeowners"})
+	}
+	return filesFound[0], shadowed
+}
+
+// FindConfig looks for a configuration file next to the CODEOWNERS file the platform uses, then at the root of the given directory.
+// It returns an empty path when there is none.
+func (p Profile) FindConfig(dir string) string {
+	dirs := []string{dir}
+	if fileLocation, err := p.FindCodeownersFile(dir); err == nil {
+		dirs = append([]string{filepath.Join(dir, filepath.Dir(fileLocation))}, dirs...)
+	}
+
+	for _, d := range dirs {
+		for _, name := range ConfigFileNames {
+			path := filepath.Join(d, name)
+			if info, err := os.Stat(path); err == nil && !info.IsDir() {
+				return path
+			}
+		}
+	}
+	return ""
+}
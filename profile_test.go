@@ -0,0 +1,150 @@
+package codeowners_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/fmenezes/codeowners"
+)
+
+// codeownersDir creates a temporary directory holding a CODEOWNERS file at every given location
+func codeownersDir(t *testing.T, content string, locations ...string) string {
+	dir, err := ioutil.TempDir("", "codeowners")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, location := range locations {
+		path := filepath.Join(dir, location)
+		if err = os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err = ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestPlatformProfile(t *testing.T) {
+	testCases := []struct {
+		platform string
+		want     codeowners.Profile
+		wantErr  bool
+	}{
+		{platform: "", want: codeowners.GithubProfile},
+		{platform: "github", want: codeowners.GithubProfile},
+		{platform: "gitlab", want: codeowners.GitlabProfile},
+		{platform: "bitbucket", want: codeowners.BitbucketProfile},
+		{platform: "gitea", want: codeowners.GiteaProfile},
+		{platform: "svn", wantErr: true},
+	}
+	for _, testCase := range testCases {
+		got, err := codeowners.PlatformProfile(testCase.platform)
+		if (err != nil) != testCase.wantErr || !reflect.DeepEqual(got, testCase.want) {
+			t.Errorf("Input: %v, Want: %v, Got: %v %v", testCase.platform, testCase.want, got, err)
+		}
+	}
+}
+
+func TestProfileFindCodeownersFile(t *testing.T) {
+	testCases := []struct {
+		profile   codeowners.Profile
+		locations []string
+		want      string
+		wantErr   bool
+	}{
+		{profile: codeowners.GithubProfile, locations: []string{"docs/CODEOWNERS", ".github/CODEOWNERS"}, want: ".github/CODEOWNERS"},
+		{profile: codeowners.GithubProfile, locations: []string{"docs/CODEOWNERS", "CODEOWNERS"}, want: "CODEOWNERS"},
+		{profile: codeowners.GitlabProfile, locations: []string{".gitlab/CODEOWNERS", "docs/CODEOWNERS"}, want: "docs/CODEOWNERS"},
+		{profile: codeowners.GiteaProfile, locations: []string{".gitea/CODEOWNERS", ".github/CODEOWNERS"}, want: ".gitea/CODEOWNERS"},
+		{profile: codeowners.BitbucketProfile, locations: []string{".bitbucket/CODEOWNERS", "CODEOWNERS"}, want: ".bitbucket/CODEOWNERS"},
+		{profile: codeowners.BitbucketProfile, locations: []string{"CODEOWNERS"}, wantErr: true},
+	}
+	for _, testCase := range testCases {
+		dir := codeownersDir(t, "* @owner\n", testCase.locations...)
+		got, err := testCase.profile.FindCodeownersFile(dir)
+		os.RemoveAll(dir)
+		if (err != nil) != testCase.wantErr || got != testCase.want {
+			t.Errorf("Input: %s %v, Want: %v, Got: %v %v", testCase.profile.Platform, testCase.locations, testCase.want, got, err)
+		}
+	}
+}
+
+func TestCheckUsesHighestPrecedenceFile(t *testing.T) {
+	dir := codeownersDir(t, "* @owner\n", "docs/CODEOWNERS", "CODEOWNERS", ".github/CODEOWNERS")
+	defer os.RemoveAll(dir)
+
+	got, err := codeowners.Check(codeowners.CheckOptions{
+		Directory: dir,
+		Checkers:  []string{dummyCheckerName},
+	})
+	want := []codeowners.CheckResult{
+		{
+			Position:  codeowners.Position{FilePath: ".github/CODEOWNERS", StartLine: 1, EndLine: 1},
+			Message:   "Dummy Error",
+			Severity:  codeowners.Error,
+			CheckName: dummyCheckerName,
+		},
+		{
+			Position:  codeowners.Position{FilePath: "CODEOWNERS"},
+			Message:   "CODEOWNERS file is shadowed by .github/CODEOWNERS, which github reads first",
+			Severity:  codeowners.Warning,
+			CheckName: "MultipleCodeowners",
+		},
+		{
+			Position:  codeowners.Position{FilePath: "docs/CODEOWNERS"},
+			Message:   "CODEOWNERS file is shadowed by .github/CODEOWNERS, which github reads first",
+			Severity:  codeowners.Warning,
+			CheckName: "MultipleCodeowners",
+		},
+	}
+	if err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("Want: %v, Got: %v %v", want, got, err)
+	}
+}
+
+func TestCheckCodeownersTooLarge(t *testing.T) {
+	dir := codeownersDir(t, strings.Repeat("* @owner\n", 400000), ".github/CODEOWNERS")
+	defer os.RemoveAll(dir)
+
+	want := []codeowners.CheckResult{
+		{
+			Position:  codeowners.Position{FilePath: ".github/CODEOWNERS"},
+			Message:   "CODEOWNERS file is 3600000 bytes, github ignores files larger than 3145728 bytes",
+			Severity:  codeowners.Error,
+			CheckName: "CodeownersTooLarge",
+		},
+	}
+	got, err := codeowners.Check(codeowners.CheckOptions{
+		Directory: dir,
+		Checkers:  []string{dummyCheckerName},
+	})
+	if err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("Want: %v, Got: %v %v", want, got, err)
+	}
+
+	got, err = codeowners.Check(codeowners.CheckOptions{
+		Directory: dir,
+		Checkers:  []string{dummyCheckerName},
+		Platform:  codeowners.GiteaPlatform,
+	})
+	if err != nil || len(got) == 0 || got[0].CheckName != "NoCodeowners" {
+		t.Errorf("Want: NoCodeowners, Got: %v %v", got, err)
+	}
+}
+
+func TestCheckUnknownPlatform(t *testing.T) {
+	_, err := codeowners.Check(codeowners.CheckOptions{
+		Directory: ".",
+		Checkers:  []string{dummyCheckerName},
+		Platform:  "svn",
+	})
+	want := "Unknown platform 'svn'"
+	if err == nil || err.Error() != want {
+		t.Errorf("Want: %v, Got: %v", want, err)
+	}
+}
@@ -0,0 +1,282 @@
+package codeowners
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Rule represents a single CODEOWNERS line that can be matched against file paths
+type Rule struct {
+	Token
+	lineNo  int
+	matcher *Pattern
+	section *Section
+}
+
+// LineNo returns the line number where this rule was declared
+func (r Rule) LineNo() int {
+	return r.lineNo
+}
+
+// Section returns the section this rule belongs to, nil for rules declared before the first section header
+// or in dialects without sections
+func (r Rule) Section() *Section {
+	return r.section
+}
+
+// Matches returns true if the given path is matched by this rule's pattern
+func (r Rule) Matches(path string) bool {
+	return r.matcher.Matches(path)
+}
+
+// Ruleset holds all rules of a CODEOWNERS file in the order they were declared
+type Ruleset struct {
+	rules   []Rule
+	dialect Dialect
+	groups  map[string][]string
+}
+
+// NewRuleset reads all tokens from the decoder and compiles them into a Ruleset following GithubDialect
+func NewRuleset(d *Decoder) (*Ruleset, error) {
+	return NewDialectRuleset(d, GithubDialect)
+}
+
+// NewDialectRuleset reads all tokens from the decoder and compiles them into a Ruleset following dialect.
+// In dialects with sections, rules declared without owners are owned by the default owners of their section.
+// In dialects with reviewer groups, group definitions such as @@@group @user are kept apart from rules, see Match.
+func NewDialectRuleset(d *Decoder, dialect Dialect) (*Ruleset, error) {
+	ruleset := &Ruleset{dialect: dialect, groups: make(map[string][]string)}
+	var section *Section
+	for d.More() {
+		if dialect.HasSections() || dialect.HasReviewerGroups() {
+			node := ParseDialectNode(d.lineNo, d.line, dialect)
+			if node.Kind == GroupNode {
+				name := "@@" + strings.TrimPrefix(node.Pattern.Value, "@@@")
+				for _, owner := range node.Owners {
+					ruleset.groups[name] = append(ruleset.groups[name], owner.Value)
+				}
+				continue
+			}
+			if node.Kind == SectionNode {
+				if len(node.Section.Invalid) > 0 {
+					return nil, fmt.Errorf("Invalid section on line %d: %s", d.lineNo, node.Section.Invalid)
+				}
+				section = node.Section
+				continue
+			}
+		}
+		token, lineNo := d.Token()
+		if section != nil && len(token.owners) == 0 {
+			for _, owner := range section.Owners {
+				token.owners = append(token.owners, owner.Value)
+			}
+		}
+		matcher, err := CompilePattern(token.Path(), dialect)
+		if err != nil {
+			return nil, err
+		}
+		ruleset.rules = append(ruleset.rules, Rule{
+			Token:   token,
+			lineNo:  lineNo,
+			matcher: matcher,
+			section: section,
+		})
+	}
+	return ruleset, nil
+}
+
+// Rules returns all rules in the order they were declared
+func (r *Ruleset) Rules() []Rule {
+	return r.rules
+}
+
+// Match finds the owners of the given path, it returns nil if no rule matches.
+// Following GitHub's semantics the last matching rule wins. In dialects combining matches, such as GiteaDialect,
+// the owners of every matching rule are returned in declaration order, without duplicates, along with the last matching rule.
+// In dialects with sections, such as GitlabDialect, the owners of the rule winning in each section are combined the same way,
+// the rule returned is the one winning in the last section.
+// In dialects with reviewer groups, such as BitbucketDialect, groups defined in the file are replaced by their members
+// and other groups are returned without their selection strategy, so @@group:random(2) owners are returned as @@group.
+func (r *Ruleset) Match(path string) ([]string, *Rule) {
+	owners, rule := r.match(path)
+	if r.dialect.HasReviewerGroups() {
+		owners = r.expandGroups(owners)
+	}
+	return owners, rule
+}
+
+func (r *Ruleset) match(path string) ([]string, *Rule) {
+	if r.dialect.CombinesMatches() {
+		return r.matchAll(path)
+	}
+	if r.dialect.HasSections() {
+		return combineOwners(r.MatchSections(path))
+	}
+	for i := len(r.rules) - 1; i >= 0; i-- {
+		if r.rules[i].Matches(path) {
+			return r.rules[i].Owners(), &r.rules[i]
+		}
+	}
+	return nil, nil
+}
+
+// expandGroups replaces the groups defined in the file by their members and strips selection strategies, without duplicates.
+// Groups defined with other groups are expanded as well, owners with malformed strategies are kept as written.
+func (r *Ruleset) expandGroups(owners []string) []string {
+	var expanded []string
+	seen := make(map[string]bool)
+	var expand func(owners []string)
+	expand = func(owners []string) {
+		for _, owner := range owners {
+			name := owner
+			if parsed, err := ParseOwner(owner, r.dialect); err == nil {
+				name = parsed.Name
+			}
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+			if members, found := r.groups[name]; found {
+				expand(members)
+				continue
+			}
+			expanded = append(expanded, name)
+		}
+	}
+	expand(owners)
+	return expanded
+}
+
+func (r *Ruleset) matchAll(path string) ([]string, *Rule) {
+	var matches []*Rule
+	for i := range r.rules {
+		if r.rules[i].Matches(path) {
+			matches = append(matches, &r.rules[i])
+		}
+	}
+	return combineOwners(matches)
+}
+
+// MatchSections finds the last rule matching the given path in every section, in the order sections are first declared.
+// Rules declared before the first section header form a section of their own, sections sharing a name case insensitively
+// are the same section. In dialects without sections it returns at most the last matching rule.
+func (r *Ruleset) MatchSections(path string) []*Rule {
+	var sections []string
+	winners := make(map[string]*Rule)
+	for i := range r.rules {
+		key := ""
+		if r.rules[i].section != nil {
+			key = "[" + strings.ToLower(r.rules[i].section.Name.Value)
+		}
+		if _, found := winners[key]; !found {
+			sections = append(sections, key)
+			winners[key] = nil
+		}
+		if r.rules[i].Matches(path) {
+			winners[key] = &r.rules[i]
+		}
+	}
+
+	var matches []*Rule
+	for _, key := range sections {
+		if winners[key] != nil {
+			matches = append(matches, winners[key])
+		}
+	}
+	return matches
+}
+
+// combineOwners returns the owners of rules in order, without duplicates, along with the last rule
+func combineOwners(rules []*Rule) ([]string, *Rule) {
+	var owners []string
+	var last *Rule
+	seen := make(map[string]bool)
+	for _, rule := range rules {
+		last = rule
+		for _, owner := range rule.Owners() {
+			if !seen[owner] {
+				seen[owner] = true
+				owners = append(owners, owner)
+			}
+		}
+	}
+	return owners, last
+}
+
+// normalisePath converts a path into the form patterns are matched against
+func normalisePath(path string) string {
+	path = strings.TrimPrefix(path, "./")
+	return strings.TrimPrefix(path, "/")
+}
+
+// compilePattern converts a gitignore style pattern into a regular expression.
+// Patterns containing a slash (other than a trailing one) are anchored to the repository root,
+// otherwise they match at any depth. A trailing slash only matches directory contents,
+// `*` and `?` never cross a slash, `**` matches any number of directories and
+// a trailing `/*` only matches direct children.
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	dirOnly := strings.HasSuffix(pattern, "/")
+	trimmed := strings.Trim(pattern, "/")
+	anchored := strings.Contains(trimmed, "/") || strings.HasPrefix(pattern, "/")
+
+	var expr strings.Builder
+	expr.WriteString("^")
+	if !anchored {
+		expr.WriteString("(?:.*/)?")
+	}
+
+	segments := strings.Split(trimmed, "/")
+	for i, segment := range segments {
+		last := i == len(segments)-1
+		if segment == "**" {
+			if last {
+				expr.WriteString(".*")
+			} else {
+				expr.WriteString("(?:.*/)?")
+			}
+			continue
+		}
+		expr.WriteString(segmentExpr(segment))
+		if !last {
+			expr.WriteString("/")
+		}
+	}
+
+	switch {
+	case segments[len(segments)-1] == "**":
+	case trimmed == "":
+		expr.WriteString(".*")
+	case dirOnly:
+		expr.WriteString("/.*")
+	case segments[len(segments)-1] != "*":
+		expr.WriteString("(?:/.*)?")
+	}
+	expr.WriteString("$")
+
+	return regexp.Compile(expr.String())
+}
+
+// segmentExpr converts a single path segment into a regular expression
+func segmentExpr(segment string) string {
+	var expr strings.Builder
+	escaped := false
+	for _, r := range segment {
+		if escaped {
+			expr.WriteString(regexp.QuoteMeta(string(r)))
+			escaped = false
+			continue
+		}
+		switch r {
+		case '\\':
+			escaped = true
+		case '*':
+			expr.WriteString("[^/]*")
+		case '?':
+			expr.WriteString("[^/]")
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	return expr.String()
+}
@@ -0,0 +1,298 @@
+package codeowners_test
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/fmenezes/codeowners"
+)
+
+func TestRulesetMatch(t *testing.T) {
+	testCases := []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		{pattern: "*", path: "file.txt", want: true},
+		{pattern: "*", path: "dir/file.txt", want: true},
+		{pattern: "*.js", path: "app.js", want: true},
+		{pattern: "*.js", path: "src/lib/app.js", want: true},
+		{pattern: "*.js", path: "app.jsx", want: false},
+		{pattern: "/build/logs/", path: "build/logs/output.log", want: true},
+		{pattern: "/build/logs/", path: "build/logs/deep/output.log", want: true},
+		{pattern: "/build/logs/", path: "src/build/logs/output.log", want: false},
+		{pattern: "/build/logs/", path: "build/logs", want: false},
+		{pattern: "docs/*", path: "docs/getting-started.md", want: true},
+		{pattern: "docs/*", path: "docs/build-app/troubleshooting.md", want: false},
+		{pattern: "docs/*", path: "src/docs/getting-started.md", want: false},
+		{pattern: "apps/", path: "apps/main.go", want: true},
+		{pattern: "apps/", path: "src/apps/main.go", want: true},
+		{pattern: "/docs/", path: "docs/a/b.md", want: true},
+		{pattern: "/docs/", path: "src/docs/a.md", want: false},
+		{pattern: "file.txt", path: "file.txt", want: true},
+		{pattern: "file.txt", path: "dir/file.txt", want: true},
+		{pattern: "/file.txt", path: "dir/file.txt", want: false},
+		{pattern: "scripts", path: "scripts/run.sh", want: true},
+		{pattern: "**/logs", path: "logs/a.log", want: true},
+		{pattern: "**/logs", path: "deep/down/logs/a.log", want: true},
+		{pattern: "/src/**", path: "src/a/b/c.go", want: true},
+		{pattern: "/src/**", path: "other/src/a.go", want: false},
+		{pattern: "a/**/b", path: "a/b", want: true},
+		{pattern: "a/**/b", path: "a/x/y/b", want: true},
+		{pattern: "a/**/b", path: "a/x/y/c", want: false},
+		{pattern: "file?.txt", path: "file1.txt", want: true},
+		{pattern: "file?.txt", path: "file/.txt", want: false},
+		{pattern: "file\\ with\\ spaces", path: "file with spaces", want: true},
+		{pattern: "\\#file", path: "#file", want: true},
+		{pattern: "\\#file", path: "file", want: false},
+		{pattern: "File.txt", path: "file.txt", want: false},
+		{pattern: "file.txt", path: "./file.txt", want: true},
+		{pattern: "file.txt", path: "/file.txt", want: true},
+		{pattern: "a+b.txt", path: "a+b.txt", want: true},
+		{pattern: "a.txt", path: "abtxt", want: false},
+	}
+
+	for _, testCase := range testCases {
+		ruleset, err := codeowners.NewRuleset(codeowners.NewDecoder(strings.NewReader(testCase.pattern + " @owner")))
+		if err != nil {
+			t.Errorf("Input: %s, Error: %v", testCase.pattern, err)
+			continue
+		}
+		_, rule := ruleset.Match(testCase.path)
+		got := rule != nil
+		if got != testCase.want {
+			t.Errorf("Input: %s %s, Want: %v, Got: %v", testCase.pattern, testCase.path, testCase.want, got)
+		}
+	}
+}
+
+func TestRulesetLastMatchWins(t *testing.T) {
+	ruleset, err := codeowners.NewRuleset(codeowners.NewDecoder(strings.NewReader(`* @default
+# comment
+
+*.go @gophers @reviewers
+/vendor/
+`)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		path       string
+		wantOwners []string
+		wantLine   int
+	}{
+		{path: "README.md", wantOwners: []string{"@default"}, wantLine: 1},
+		{path: "main.go", wantOwners: []string{"@gophers", "@reviewers"}, wantLine: 4},
+		{path: "vendor/lib/lib.go", wantOwners: nil, wantLine: 5},
+	}
+
+	for _, testCase := range testCases {
+		owners, rule := ruleset.Match(testCase.path)
+		if rule == nil {
+			t.Errorf("Input: %s, Want: match, Got: no match", testCase.path)
+			continue
+		}
+		if !reflect.DeepEqual(owners, testCase.wantOwners) || rule.LineNo() != testCase.wantLine {
+			t.Errorf("Input: %s, Want: %v line %d, Got: %v line %d", testCase.path, testCase.wantOwners, testCase.wantLine, owners, rule.LineNo())
+		}
+	}
+}
+
+func TestRulesetNoMatch(t *testing.T) {
+	ruleset, err := codeowners.NewRuleset(codeowners.NewDecoder(strings.NewReader(`/docs/ @writers`)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	owners, rule := ruleset.Match("src/main.go")
+	if owners != nil || rule != nil {
+		t.Errorf("Want no match, Got: %v %v", owners, rule)
+	}
+	if len(ruleset.Rules()) != 1 {
+		t.Errorf("Want 1 rule, Got: %d", len(ruleset.Rules()))
+	}
+}
+
+func TestGiteaRulesetMatch(t *testing.T) {
+	ruleset, err := codeowners.NewDialectRuleset(codeowners.NewDecoder(strings.NewReader(`.* @default
+.*\.go @gophers @default
+docs/.* @writers
+!.*\.(go|md) @others
+`)), codeowners.GiteaDialect)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		path       string
+		wantOwners []string
+		wantLine   int
+	}{
+		{path: "main.go", wantOwners: []string{"@default", "@gophers"}, wantLine: 2},
+		{path: "docs/index.md", wantOwners: []string{"@default", "@writers"}, wantLine: 3},
+		{path: "docs/run.sh", wantOwners: []string{"@default", "@writers", "@others"}, wantLine: 4},
+		{path: "src/docs/index.md", wantOwners: []string{"@default"}, wantLine: 1},
+		{path: "/Makefile", wantOwners: []string{"@default", "@others"}, wantLine: 4},
+	}
+
+	for _, testCase := range testCases {
+		owners, rule := ruleset.Match(testCase.path)
+		if rule == nil {
+			t.Errorf("Input: %s, Want: match, Got: no match", testCase.path)
+			continue
+		}
+		if !reflect.DeepEqual(owners, testCase.wantOwners) || rule.LineNo() != testCase.wantLine {
+			t.Errorf("Input: %s, Want: %v line %d, Got: %v line %d", testCase.path, testCase.wantOwners, testCase.wantLine, owners, rule.LineNo())
+		}
+	}
+}
+
+func TestGitlabRulesetMatch(t *testing.T) {
+	ruleset, err := codeowners.NewDialectRuleset(codeowners.NewDecoder(strings.NewReader(`* @default
+*.md @writers
+
+[Documentation] @docs-team
+docs/
+docs/api/ @api-writers
+
+^[Backend][2] @backend
+*.go
+[documentation]
+*.md @editors
+`)), codeowners.GitlabDialect)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		path         string
+		wantOwners   []string
+		wantLine     int
+		wantSections int
+	}{
+		{path: "main.go", wantOwners: []string{"@default", "@backend"}, wantLine: 9, wantSections: 2},
+		{path: "docs/index.md", wantOwners: []string{"@writers", "@editors"}, wantLine: 11, wantSections: 2},
+		{path: "docs/api/spec.yaml", wantOwners: []string{"@default", "@api-writers"}, wantLine: 6, wantSections: 2},
+		{path: "docs/install.sh", wantOwners: []string{"@default", "@docs-team"}, wantLine: 5, wantSections: 2},
+		{path: "Makefile", wantOwners: []string{"@default"}, wantLine: 1, wantSections: 1},
+	}
+
+	for _, testCase := range testCases {
+		owners, rule := ruleset.Match(testCase.path)
+		if rule == nil {
+			t.Errorf("Input: %s, Want: match, Got: no match", testCase.path)
+			continue
+		}
+		if !reflect.DeepEqual(owners, testCase.wantOwners) || rule.LineNo() != testCase.wantLine {
+			t.Errorf("Input: %s, Want: %v line %d, Got: %v line %d", testCase.path, testCase.wantOwners, testCase.wantLine, owners, rule.LineNo())
+		}
+		if got := len(ruleset.MatchSections(testCase.path)); got != testCase.wantSections {
+			t.Errorf("Input: %s, Want: %d sections, Got: %d", testCase.path, testCase.wantSections, got)
+		}
+	}
+
+	rule := ruleset.MatchSections("main.go")[1]
+	if section := rule.Section(); section == nil || section.Name.Value != "Backend" || !section.Optional || section.Approvals != 2 {
+		t.Errorf("Want: optional section Backend requiring 2 approvals, Got: %v", section)
+	}
+}
+
+func TestGitlabRulesetInvalidSection(t *testing.T) {
+	_, err := codeowners.NewDialectRuleset(codeowners.NewDecoder(strings.NewReader("[Docs\ndocs/ @writers\n")), codeowners.GitlabDialect)
+	want := "Invalid section on line 1: missing closing bracket"
+	if err == nil || err.Error() != want {
+		t.Errorf("Want: %v, Got: %v", want, err)
+	}
+}
+
+func TestBitbucketRulesetMatch(t *testing.T) {
+	ruleset, err := codeowners.NewDialectRuleset(codeowners.NewDecoder(strings.NewReader(`@@@Frontend @alice @bob
+* @@Developers
+*.js @@Frontend:random(2) @carol
+`)), codeowners.BitbucketDialect)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := len(ruleset.Rules()); got != 2 {
+		t.Errorf("Want: 2 rules, Got: %d", got)
+	}
+
+	owners, rule := ruleset.Match("app/index.js")
+	want := []string{"@alice", "@bob", "@carol"}
+	if rule == nil || rule.LineNo() != 3 || !reflect.DeepEqual(owners, want) {
+		t.Errorf("Want: %v, Got: %v", want, owners)
+	}
+}
+
+func TestBitbucketRulesetMatchGroups(t *testing.T) {
+	ruleset, err := codeowners.NewDialectRuleset(codeowners.NewDecoder(strings.NewReader(`* @@Developers:least_busy(1)
+*.js @@Frontend:random(1) @alice
+*.css @@Frontend @@Designers
+*.md @@Docs:random(x)
+@@@Frontend @alice @bob
+@@@Designers @@Frontend @dave @@Designers
+`)), codeowners.BitbucketDialect)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		path string
+		want []string
+	}{
+		{path: "main.go", want: []string{"@@Developers"}},
+		{path: "app/index.js", want: []string{"@alice", "@bob"}},
+		{path: "app/style.css", want: []string{"@alice", "@bob", "@dave"}},
+		{path: "README.md", want: []string{"@@Docs:random(x)"}},
+	}
+	for _, test := range tests {
+		owners, _ := ruleset.Match(test.path)
+		if !reflect.DeepEqual(owners, test.want) {
+			t.Errorf("Input: %v, Want: %v, Got: %v", test.path, test.want, owners)
+		}
+	}
+}
+
+func TestCompilePattern(t *testing.T) {
+	testCases := []struct {
+		pattern string
+		dialect codeowners.Dialect
+		path    string
+		want    bool
+		wantErr bool
+	}{
+		{pattern: "*.go", dialect: codeowners.GithubDialect, path: "cmd/main.go", want: true},
+		{pattern: "docs/[z-a]", dialect: codeowners.GiteaDialect, wantErr: true},
+		{pattern: `src/.*\.go`, dialect: codeowners.GiteaDialect, path: "src/main.go", want: true},
+		{pattern: `src/.*\.go`, dialect: codeowners.GiteaDialect, path: "lib/src/main.go", want: false},
+		{pattern: "!src/.*", dialect: codeowners.GiteaDialect, path: "lib/main.go", want: true},
+		{pattern: "!src/.*", dialect: codeowners.GiteaDialect, path: "src/main.go", want: false},
+		{pattern: "(docs", dialect: codeowners.GiteaDialect, wantErr: true},
+	}
+
+	for _, testCase := range testCases {
+		pattern, err := codeowners.CompilePattern(testCase.pattern, testCase.dialect)
+		if (err != nil) != testCase.wantErr {
+			t.Errorf("Input: %s %s, Want error: %v, Got: %v", testCase.pattern, testCase.dialect.Name(), testCase.wantErr, err)
+			continue
+		}
+		if err == nil && pattern.Matches(testCase.path) != testCase.want {
+			t.Errorf("Input: %s %s %s, Want: %v, Got: %v", testCase.pattern, testCase.dialect.Name(), testCase.path, testCase.want, !testCase.want)
+		}
+	}
+}
+
+func ExampleRuleset() {
+	ruleset, err := codeowners.NewRuleset(codeowners.NewDecoder(strings.NewReader(`* @default
+*.go @gophers`)))
+	if err != nil {
+		panic(err)
+	}
+	owners, rule := ruleset.Match("cmd/main.go")
+	fmt.Printf("Owners: %v\n", owners)
+	fmt.Printf("Rule: %s (line %d)\n", rule.Path(), rule.LineNo())
+	// Output:
+	// Owners: [@gophers]
+	// Rule: *.go (line 2)
+}
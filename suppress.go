@@ -0,0 +1,168 @@
+package codeowners
+
+import (
+	"fmt"
+	"strings"
+)
+
+const suppressionPrefix = "codeownerslint:"
+
+const unusedSuppressionCheckName = "UnusedSuppression"
+const invalidSuppressionCheckName = "InvalidSuppression"
+
+// builtinCheckNames are the check names of results reported by every check, whichever checkers run
+var builtinCheckNames = []string{"NoCodeowners", "MultipleCodeowners", "CodeownersTooLarge", unusedSuppressionCheckName, invalidSuppressionCheckName}
+
+// suppression silences results of a check within a range of lines, an empty check silences every check
+type suppression struct {
+	node      Node
+	checkName string
+	single    bool // single is true when the directive only names this check
+	fromLine  int
+	toLine    int // toLine is 0 while the range is open until the end of the file
+	used      bool
+}
+
+func (s *suppression) covers(result CheckResult) bool {
+	if len(s.checkName) > 0 && s.checkName != result.CheckName {
+		return false
+	}
+	line := result.Position.StartLine
+	return line >= s.fromLine && (s.toLine == 0 || line <= s.toLine)
+}
+
+// parseSuppressions reads the suppression comments, such as
+// "# codeownerslint:disable-next-line NoOwner InvalidOwner" or
+// "# codeownerslint:disable InvalidOwner" followed by "# codeownerslint:enable InvalidOwner".
+// Omitting the check names applies to all checks.
+func parseSuppressions(filePath string, file *File) ([]*suppression, []CheckResult) {
+	suppressions := []*suppression{}
+	open := []*suppression{}
+	invalid := []CheckResult{}
+
+	for _, node := range file.Nodes {
+		if len(node.Comment.Value) == 0 {
+			continue
+		}
+		directive := strings.TrimSpace(strings.TrimPrefix(node.Comment.Value, "#"))
+		if !strings.HasPrefix(directive, suppressionPrefix) {
+			continue
+		}
+		fields := strings.FieldsFunc(strings.TrimPrefix(directive, suppressionPrefix), func(r rune) bool {
+			return r == ' ' || r == '\t' || r == ','
+		})
+		if len(fields) == 0 {
+			fields = []string{""}
+		}
+		checkNames := fields[1:]
+		if len(checkNames) == 0 {
+			checkNames = []string{""}
+		}
+		lineNo := node.Span.Line
+
+		switch fields[0] {
+		case "disable-next-line":
+			for _, checkName := range checkNames {
+				suppressions = append(suppressions, &suppression{node: node, checkName: checkName, single: len(checkNames) == 1, fromLine: lineNo + 1, toLine: lineNo + 1})
+			}
+		case "disable":
+			for _, checkName := range checkNames {
+				s := &suppression{node: node, checkName: checkName, single: len(checkNames) == 1, fromLine: lineNo + 1}
+				suppressions = append(suppressions, s)
+				open = append(open, s)
+			}
+		case "enable":
+			stillOpen := []*suppression{}
+			for _, s := range open {
+				closed := false
+				for _, checkName := range checkNames {
+					if checkName == "" || checkName == s.checkName {
+						closed = true
+					}
+				}
+				if closed {
+					s.toLine = lineNo - 1
+				} else {
+					stillOpen = append(stillOpen, s)
+				}
+			}
+			open = stillOpen
+		default:
+			invalid = append(invalid, CheckResult{
+				Position:  node.Comment.Span.Position(filePath),
+				Message:   fmt.Sprintf("Unknown suppression directive '%s'", fields[0]),
+				Severity:  Warning,
+				CheckName: invalidSuppressionCheckName,
+			})
+		}
+	}
+
+	return suppressions, invalid
+}
+
+// applySuppressions removes the results silenced by suppression comments and reports
+// suppressions that did not silence anything, so they do not outlive the problem they were added for.
+// Suppressions of checks that are registered but did not run are left alone, those of unknown checks are reported.
+func applySuppressions(filePath string, file *File, checkers []string, results []CheckResult) []CheckResult {
+	suppressions, filtered := parseSuppressions(filePath, file)
+
+	for _, result := range results {
+		suppressed := false
+		for _, s := range suppressions {
+			if s.covers(result) {
+				s.used = true
+				suppressed = true
+			}
+		}
+		if !suppressed {
+			filtered = append(filtered, result)
+		}
+	}
+
+	ran := checkNames(checkers)
+	for _, name := range builtinCheckNames {
+		ran[name] = true
+	}
+	registered := checkNames(AvailableCheckers())
+	for _, s := range suppressions {
+		if s.used || (registered[s.checkName] && !ran[s.checkName]) {
+			continue
+		}
+		name := fmt.Sprintf("'%s'", s.checkName)
+		switch {
+		case len(s.checkName) == 0:
+			name = "all checks"
+		case !ran[s.checkName]:
+			name = fmt.Sprintf("unknown check '%s'", s.checkName)
+		}
+		result := CheckResult{
+			Position:  s.node.Comment.Span.Position(filePath),
+			Message:   fmt.Sprintf("Suppression of %s is unused", name),
+			Severity:  Warning,
+			CheckName: unusedSuppressionCheckName,
+		}
+		if s.single && s.node.Kind == CommentNode {
+			result.SuggestedFix = &SuggestedFix{
+				Message: "Remove suppression comment",
+				Edits:   []TextEdit{DeleteLine(filePath, s.node.Span.Line)},
+			}
+		}
+		filtered = append(filtered, result)
+	}
+
+	return filtered
+}
+
+// checkNames returns the check names of the results the checkers may report, their own names and their ResultNames
+func checkNames(checkers []string) map[string]bool {
+	names := make(map[string]bool)
+	for _, checker := range checkers {
+		names[checker] = true
+		if describer, ok := availableCheckers[checker].(Describer); ok {
+			for _, name := range describer.Describe().ResultNames {
+				names[name] = true
+			}
+		}
+	}
+	return names
+}
@@ -0,0 +1,119 @@
+package codeowners_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/fmenezes/codeowners"
+)
+
+const badCheckerName string = "Bad"
+const disabledCheckerName string = "Disabled"
+
+type badChecker struct{}
+
+type badCheckerValidator struct {
+	codeownersFileLocation string
+}
+
+func (c badChecker) NewValidator(options codeowners.ValidatorOptions) codeowners.Validator {
+	return badCheckerValidator{
+		codeownersFileLocation: options.CodeownersFileLocation,
+	}
+}
+
+func (c badCheckerValidator) ValidateLine(lineNo int, line string) []codeowners.CheckResult {
+	if !strings.HasSuffix(line, "bad") {
+		return nil
+	}
+	return []codeowners.CheckResult{
+		{
+			Position: codeowners.Position{
+				FilePath:  c.codeownersFileLocation,
+				StartLine: lineNo,
+				EndLine:   lineNo,
+			},
+			Message:   "Bad line",
+			Severity:  codeowners.Error,
+			CheckName: badCheckerName,
+		},
+	}
+}
+
+func TestSuppressions(t *testing.T) {
+	input := "./test/data/suppressions"
+	badResult := func(lineNo int) codeowners.CheckResult {
+		return codeowners.CheckResult{
+			Position: codeowners.Position{
+				FilePath:  "CODEOWNERS",
+				StartLine: lineNo,
+				EndLine:   lineNo,
+			},
+			Message:   "Bad line",
+			Severity:  codeowners.Error,
+			CheckName: badCheckerName,
+		}
+	}
+	want := []codeowners.CheckResult{
+		badResult(3),
+		badResult(7),
+		{
+			Position: codeowners.Position{
+				FilePath:    "CODEOWNERS",
+				StartLine:   8,
+				StartColumn: 1,
+				EndLine:     8,
+				EndColumn:   39,
+			},
+			Message:   "Suppression of 'Bad' is unused",
+			Severity:  codeowners.Warning,
+			CheckName: "UnusedSuppression",
+			SuggestedFix: &codeowners.SuggestedFix{
+				Message: "Remove suppression comment",
+				Edits:   []codeowners.TextEdit{codeowners.DeleteLine("CODEOWNERS", 8)},
+			},
+		},
+		{
+			Position: codeowners.Position{
+				FilePath:    "CODEOWNERS",
+				StartLine:   12,
+				StartColumn: 1,
+				EndLine:     12,
+				EndColumn:   26,
+			},
+			Message:   "Unknown suppression directive 'whatever'",
+			Severity:  codeowners.Warning,
+			CheckName: "InvalidSuppression",
+		},
+		{
+			Position: codeowners.Position{
+				FilePath:    "CODEOWNERS",
+				StartLine:   15,
+				StartColumn: 1,
+				EndLine:     15,
+				EndColumn:   42,
+			},
+			Message:   "Suppression of unknown check 'NoOwnr' is unused",
+			Severity:  codeowners.Warning,
+			CheckName: "UnusedSuppression",
+			SuggestedFix: &codeowners.SuggestedFix{
+				Message: "Remove suppression comment",
+				Edits:   []codeowners.TextEdit{codeowners.DeleteLine("CODEOWNERS", 15)},
+			},
+		},
+	}
+
+	codeowners.RegisterChecker(badCheckerName, badChecker{})
+	codeowners.RegisterChecker(disabledCheckerName, badChecker{})
+	got, err := codeowners.Check(codeowners.CheckOptions{
+		Directory: input,
+		Checkers:  []string{badCheckerName},
+	})
+	if err != nil {
+		t.Errorf("Input %s, Error %v", input, err)
+	}
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("Input %s, Want %v, Got %v", input, want, got)
+	}
+}
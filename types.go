@@ -1,27 +1,80 @@
 package codeowners
 
 import (
+	"context"
 	"fmt"
+	"strings"
 )
 
 // ValidatorOptions provide input arguments for checkers to use
 type ValidatorOptions struct {
-	Directory              string
-	CodeownersFileLocation string
-	GithubTokenType        string
-	GithubToken            string
+	Context                 context.Context // Context is the context of the running check, validators doing I/O should honour its cancellation
+	Directory               string
+	CodeownersFileLocation  string
+	GithubTokenType         string
+	GithubToken             string
+	GithubURL               string            // GithubURL is the API base URL of a Github Enterprise Server, github.com is used when empty
+	GithubUploadURL         string            // GithubUploadURL is the upload base URL of a Github Enterprise Server, GithubURL is used when empty
+	CAFile                  string            // CAFile is a PEM bundle of extra certificate authorities trusted when calling the platform
+	Proxy                   string            // Proxy is the proxy URL used when calling the platform, the environment proxy is used when empty
+	GithubAppID             int64             // GithubAppID authenticates as this Github App instead of using GithubToken
+	GithubAppPrivateKeyFile string            // GithubAppPrivateKeyFile is the PEM file of the Github App private key
+	GithubAppInstallationID int64             // GithubAppInstallationID is the installation of the Github App, looked up from the repository when zero
+	Repository              string            // Repository is the owner/name of the repository, or the full path of the GitLab project, resolved from Remote when empty
+	Remote                  string            // Remote is the git remote the repository is resolved from, origin when empty
+	Platform                string            // Platform is the platform hosting the repository, GithubPlatform when empty
+	GitlabURL               string            // GitlabURL is the API base URL of a self-managed GitLab, gitlab.com is used when empty
+	GitlabToken             string            // GitlabToken is the GitLab personal, group or project access token
+	GiteaURL                string            // GiteaURL is the API base URL of the Gitea server, such as https://gitea.example.com/api/v1
+	GiteaToken              string            // GiteaToken is the Gitea access token
+	Options                 map[string]string // Options are the settings specific to the checker
 }
 
+// Platforms hosting repositories, they tell the Access checker where owners are looked up
+const (
+	GithubPlatform    string = "github"    // GithubPlatform is github.com or a Github Enterprise Server
+	GitlabPlatform    string = "gitlab"    // GitlabPlatform is gitlab.com or a self-managed GitLab
+	GiteaPlatform     string = "gitea"     // GiteaPlatform is a Gitea or Forgejo server
+	BitbucketPlatform string = "bitbucket" // BitbucketPlatform is Bitbucket Cloud or a Bitbucket Data Center, owners are not looked up
+)
+
 // Checker provides tools for validating CODEOWNER file contents
 type Checker interface {
 	NewValidator(options ValidatorOptions) Validator
 }
 
+// CheckerInfo provides metadata about a checker
+type CheckerInfo struct {
+	Name          string
+	Description   string
+	Severity      SeverityLevel // Severity is the default severity of the checker's results
+	RequiresToken bool          // RequiresToken is true when the checker is skipped without a Github token
+	ResultNames   []string      // ResultNames are the check names of results reported besides the checker's own name
+}
+
+// Describer is implemented by checkers providing metadata about themselves
+type Describer interface {
+	Describe() CheckerInfo
+}
+
+// OptionsValidator is implemented by checkers validating their options, so invalid settings are rejected before checking
+type OptionsValidator interface {
+	ValidateOptions(options map[string]string) error
+}
+
 // Validator provides tools for validating CODEOWNER file contents
 type Validator interface {
 	ValidateLine(lineNo int, line string) []CheckResult
 }
 
+// FileValidator can be implemented by validators that need to reason about the whole file, such as relationships between rules.
+// Check calls Begin before validating any line, ValidateRule for every rule after its ValidateLine call, and Finish once every line was validated.
+type FileValidator interface {
+	Begin(file *File)
+	ValidateRule(rule Node) []CheckResult
+	Finish() []CheckResult
+}
+
 // SeverityLevel exposes all possible levels of severity check results
 type SeverityLevel int
 
@@ -36,6 +89,16 @@ func (l SeverityLevel) Name() string {
 	return [...]string{"Error", "Warning"}[l]
 }
 
+// ParseSeverityLevel converts a case insensitive name, such as "error" or "warning", into a severity level
+func ParseSeverityLevel(name string) (SeverityLevel, error) {
+	for _, l := range []SeverityLevel{Error, Warning} {
+		if strings.EqualFold(l.Name(), name) {
+			return l, nil
+		}
+	}
+	return Error, fmt.Errorf("Unknown severity level '%s'", name)
+}
+
 // Position provides structured way to evaluate where a given validation result is located in the CODEOWNERs file
 type Position struct {
 	FilePath    string
@@ -60,18 +123,48 @@ func (p Position) Format() string {
 	return output
 }
 
+// TextEdit replaces the text located by Position with NewText, the end of Position is exclusive
+type TextEdit struct {
+	Position Position
+	NewText  string
+}
+
+// SuggestedFix provides a machine applicable fix for a check result, its edits are applied all together or not at all
+type SuggestedFix struct {
+	Message string
+	Edits   []TextEdit
+}
+
 // CheckResult provides structured way to evaluate results of a CODEOWNERS validation check
 type CheckResult struct {
-	Position  Position
-	Message   string
-	Severity  SeverityLevel
-	CheckName string
+	Position     Position
+	Message      string
+	Severity     SeverityLevel
+	CheckName    string
+	SuggestedFix *SuggestedFix
+	Cause        error // Cause is the underlying error when the check itself could not be completed
 }
 
 // CheckOptions provides parameters for running a list of checks
 type CheckOptions struct {
-	Directory       string
-	Checkers        []string
-	GithubTokenType string
-	GithubToken     string
+	Directory               string
+	Checkers                []string
+	GithubTokenType         string
+	GithubToken             string
+	GithubURL               string                       // GithubURL is the API base URL of a Github Enterprise Server, github.com is used when empty
+	GithubUploadURL         string                       // GithubUploadURL is the upload base URL of a Github Enterprise Server, GithubURL is used when empty
+	CAFile                  string                       // CAFile is a PEM bundle of extra certificate authorities trusted when calling the platform
+	Proxy                   string                       // Proxy is the proxy URL used when calling the platform, the environment proxy is used when empty
+	GithubAppID             int64                        // GithubAppID authenticates as this Github App instead of using GithubToken
+	GithubAppPrivateKeyFile string                       // GithubAppPrivateKeyFile is the PEM file of the Github App private key
+	GithubAppInstallationID int64                        // GithubAppInstallationID is the installation of the Github App, looked up from the repository when zero
+	Repository              string                       // Repository is the owner/name of the repository, or the full path of the GitLab project, resolved from Remote when empty
+	Remote                  string                       // Remote is the git remote the repository is resolved from, origin when empty
+	Platform                string                       // Platform is the platform hosting the repository, GithubPlatform when empty
+	GitlabURL               string                       // GitlabURL is the API base URL of a self-managed GitLab, gitlab.com is used when empty
+	GitlabToken             string                       // GitlabToken is the GitLab personal, group or project access token
+	GiteaURL                string                       // GiteaURL is the API base URL of the Gitea server, such as https://gitea.example.com/api/v1
+	GiteaToken              string                       // GiteaToken is the Gitea access token
+	Severities              map[string]SeverityLevel     // Severities overrides the severity of results by check name
+	CheckerOptions          map[string]map[string]string // CheckerOptions provides the settings specific to each checker by checker name
 }